@@ -0,0 +1,423 @@
+package appwrite
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// Attribute types as reported in AttributeOptions.Type
+const (
+	AttributeTypeString   = "string"
+	AttributeTypeInteger  = "integer"
+	AttributeTypeFloat    = "double"
+	AttributeTypeBoolean  = "boolean"
+	AttributeTypeDatetime = "datetime"
+)
+
+// Attribute formats as reported in AttributeOptions.Format
+const (
+	AttributeFormatEmail = "email"
+	AttributeFormatEnum  = "enum"
+	AttributeFormatIP    = "ip"
+	AttributeFormatURL   = "url"
+)
+
+// ListAttributes get a list of all the attributes of a collection.
+func (srv *Database) ListAttributes(databaseId, collectionId string, Queries []string) (*AttributeList, error) {
+	return srv.ListAttributesContext(context.Background(), databaseId, collectionId, Queries)
+}
+
+// ListAttributesContext is like ListAttributes but cancels the request when ctx is done.
+func (srv *Database) ListAttributesContext(ctx context.Context, databaseId, collectionId string, Queries []string) (*AttributeList, error) {
+	r := pathReplacer("{databaseId}", databaseId, "{collectionId}", collectionId)
+	path := r.Replace("/databases/{databaseId}/collections/{collectionId}/attributes")
+
+	params := map[string]interface{}{
+		"queries": Queries,
+	}
+
+	resp, err := srv.Client.CallAPIContext(ctx, "GET", path, srv.Client.headers, params)
+	if err != nil {
+		return nil, err
+	}
+	var result AttributeList
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetAttribute get an attribute of a collection by its key.
+func (srv *Database) GetAttribute(databaseId, collectionId, key string) (*Attribute, error) {
+	return srv.GetAttributeContext(context.Background(), databaseId, collectionId, key)
+}
+
+// GetAttributeContext is like GetAttribute but cancels the request when ctx is done.
+func (srv *Database) GetAttributeContext(ctx context.Context, databaseId, collectionId, key string) (*Attribute, error) {
+	r := pathReplacer("{databaseId}", databaseId, "{collectionId}", collectionId, "{key}", key)
+	path := r.Replace("/databases/{databaseId}/collections/{collectionId}/attributes/{key}")
+
+	params := map[string]interface{}{}
+
+	resp, err := srv.Client.CallAPIContext(ctx, "GET", path, srv.Client.headers, params)
+	if err != nil {
+		return nil, err
+	}
+	var result Attribute
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DeleteAttribute delete an attribute of a collection by its key.
+func (srv *Database) DeleteAttribute(databaseId, collectionId, key string) error {
+	return srv.DeleteAttributeContext(context.Background(), databaseId, collectionId, key)
+}
+
+// DeleteAttributeContext is like DeleteAttribute but cancels the request when ctx is done.
+func (srv *Database) DeleteAttributeContext(ctx context.Context, databaseId, collectionId, key string) error {
+	r := pathReplacer("{databaseId}", databaseId, "{collectionId}", collectionId, "{key}", key)
+	path := r.Replace("/databases/{databaseId}/collections/{collectionId}/attributes/{key}")
+
+	params := map[string]interface{}{}
+
+	_, err := srv.Client.CallAPIContext(ctx, "DELETE", path, srv.Client.headers, params)
+	return err
+}
+
+// CreateStringAttribute create a string attribute of at most size characters.
+func (srv *Database) CreateStringAttribute(databaseId, collectionId, key string, size int, required bool, Default *string, array bool) (*Attribute, error) {
+	return srv.CreateStringAttributeContext(context.Background(), databaseId, collectionId, key, size, required, Default, array)
+}
+
+// CreateStringAttributeContext is like CreateStringAttribute but cancels the request when ctx is done.
+func (srv *Database) CreateStringAttributeContext(ctx context.Context, databaseId, collectionId, key string, size int, required bool, Default *string, array bool) (*Attribute, error) {
+	params := map[string]interface{}{
+		"key":      key,
+		"size":     size,
+		"required": required,
+		"default":  Default,
+		"array":    array,
+	}
+	return srv.createAttribute(ctx, databaseId, collectionId, "string", params)
+}
+
+// CreateIntegerAttribute create an integer attribute, optionally bounded by
+// min and max.
+func (srv *Database) CreateIntegerAttribute(databaseId, collectionId, key string, required bool, Min *int64, Max *int64, Default *int64, array bool) (*Attribute, error) {
+	return srv.CreateIntegerAttributeContext(context.Background(), databaseId, collectionId, key, required, Min, Max, Default, array)
+}
+
+// CreateIntegerAttributeContext is like CreateIntegerAttribute but cancels the request when ctx is done.
+func (srv *Database) CreateIntegerAttributeContext(ctx context.Context, databaseId, collectionId, key string, required bool, Min *int64, Max *int64, Default *int64, array bool) (*Attribute, error) {
+	params := map[string]interface{}{
+		"key":      key,
+		"required": required,
+		"default":  Default,
+		"array":    array,
+	}
+	if Min != nil {
+		params["min"] = *Min
+	}
+	if Max != nil {
+		params["max"] = *Max
+	}
+	return srv.createAttribute(ctx, databaseId, collectionId, "integer", params)
+}
+
+// CreateFloatAttribute create a float attribute, optionally bounded by min and
+// max.
+func (srv *Database) CreateFloatAttribute(databaseId, collectionId, key string, required bool, Min *float64, Max *float64, Default *float64, array bool) (*Attribute, error) {
+	return srv.CreateFloatAttributeContext(context.Background(), databaseId, collectionId, key, required, Min, Max, Default, array)
+}
+
+// CreateFloatAttributeContext is like CreateFloatAttribute but cancels the request when ctx is done.
+func (srv *Database) CreateFloatAttributeContext(ctx context.Context, databaseId, collectionId, key string, required bool, Min *float64, Max *float64, Default *float64, array bool) (*Attribute, error) {
+	params := map[string]interface{}{
+		"key":      key,
+		"required": required,
+		"default":  Default,
+		"array":    array,
+	}
+	if Min != nil {
+		params["min"] = *Min
+	}
+	if Max != nil {
+		params["max"] = *Max
+	}
+	return srv.createAttribute(ctx, databaseId, collectionId, "float", params)
+}
+
+// CreateBooleanAttribute create a boolean attribute.
+func (srv *Database) CreateBooleanAttribute(databaseId, collectionId, key string, required bool, Default *bool, array bool) (*Attribute, error) {
+	return srv.CreateBooleanAttributeContext(context.Background(), databaseId, collectionId, key, required, Default, array)
+}
+
+// CreateBooleanAttributeContext is like CreateBooleanAttribute but cancels the request when ctx is done.
+func (srv *Database) CreateBooleanAttributeContext(ctx context.Context, databaseId, collectionId, key string, required bool, Default *bool, array bool) (*Attribute, error) {
+	params := map[string]interface{}{
+		"key":      key,
+		"required": required,
+		"default":  Default,
+		"array":    array,
+	}
+	return srv.createAttribute(ctx, databaseId, collectionId, "boolean", params)
+}
+
+// CreateEmailAttribute create an email attribute.
+func (srv *Database) CreateEmailAttribute(databaseId, collectionId, key string, required bool, Default *string, array bool) (*Attribute, error) {
+	return srv.CreateEmailAttributeContext(context.Background(), databaseId, collectionId, key, required, Default, array)
+}
+
+// CreateEmailAttributeContext is like CreateEmailAttribute but cancels the request when ctx is done.
+func (srv *Database) CreateEmailAttributeContext(ctx context.Context, databaseId, collectionId, key string, required bool, Default *string, array bool) (*Attribute, error) {
+	params := map[string]interface{}{
+		"key":      key,
+		"required": required,
+		"default":  Default,
+		"array":    array,
+	}
+	return srv.createAttribute(ctx, databaseId, collectionId, "email", params)
+}
+
+// CreateEnumAttribute create an enum attribute accepting only the given
+// elements.
+func (srv *Database) CreateEnumAttribute(databaseId, collectionId, key string, elements []string, required bool, Default *string, array bool) (*Attribute, error) {
+	return srv.CreateEnumAttributeContext(context.Background(), databaseId, collectionId, key, elements, required, Default, array)
+}
+
+// CreateEnumAttributeContext is like CreateEnumAttribute but cancels the request when ctx is done.
+func (srv *Database) CreateEnumAttributeContext(ctx context.Context, databaseId, collectionId, key string, elements []string, required bool, Default *string, array bool) (*Attribute, error) {
+	params := map[string]interface{}{
+		"key":      key,
+		"elements": elements,
+		"required": required,
+		"default":  Default,
+		"array":    array,
+	}
+	return srv.createAttribute(ctx, databaseId, collectionId, "enum", params)
+}
+
+// CreateIPAttribute create an IP address attribute.
+func (srv *Database) CreateIPAttribute(databaseId, collectionId, key string, required bool, Default *string, array bool) (*Attribute, error) {
+	return srv.CreateIPAttributeContext(context.Background(), databaseId, collectionId, key, required, Default, array)
+}
+
+// CreateIPAttributeContext is like CreateIPAttribute but cancels the request when ctx is done.
+func (srv *Database) CreateIPAttributeContext(ctx context.Context, databaseId, collectionId, key string, required bool, Default *string, array bool) (*Attribute, error) {
+	params := map[string]interface{}{
+		"key":      key,
+		"required": required,
+		"default":  Default,
+		"array":    array,
+	}
+	return srv.createAttribute(ctx, databaseId, collectionId, "ip", params)
+}
+
+// CreateURLAttribute create a URL attribute.
+func (srv *Database) CreateURLAttribute(databaseId, collectionId, key string, required bool, Default *string, array bool) (*Attribute, error) {
+	return srv.CreateURLAttributeContext(context.Background(), databaseId, collectionId, key, required, Default, array)
+}
+
+// CreateURLAttributeContext is like CreateURLAttribute but cancels the request when ctx is done.
+func (srv *Database) CreateURLAttributeContext(ctx context.Context, databaseId, collectionId, key string, required bool, Default *string, array bool) (*Attribute, error) {
+	params := map[string]interface{}{
+		"key":      key,
+		"required": required,
+		"default":  Default,
+		"array":    array,
+	}
+	return srv.createAttribute(ctx, databaseId, collectionId, "url", params)
+}
+
+// CreateDatetimeAttribute create a datetime attribute. Default must be an
+// ISO 8601 datetime.
+func (srv *Database) CreateDatetimeAttribute(databaseId, collectionId, key string, required bool, Default *string, array bool) (*Attribute, error) {
+	return srv.CreateDatetimeAttributeContext(context.Background(), databaseId, collectionId, key, required, Default, array)
+}
+
+// CreateDatetimeAttributeContext is like CreateDatetimeAttribute but cancels the request when ctx is done.
+func (srv *Database) CreateDatetimeAttributeContext(ctx context.Context, databaseId, collectionId, key string, required bool, Default *string, array bool) (*Attribute, error) {
+	params := map[string]interface{}{
+		"key":      key,
+		"required": required,
+		"default":  Default,
+		"array":    array,
+	}
+	return srv.createAttribute(ctx, databaseId, collectionId, "datetime", params)
+}
+
+// UpdateStringAttribute update the required flag and default value of a
+// string attribute.
+func (srv *Database) UpdateStringAttribute(databaseId, collectionId, key string, required bool, Default *string) (*Attribute, error) {
+	return srv.UpdateStringAttributeContext(context.Background(), databaseId, collectionId, key, required, Default)
+}
+
+// UpdateStringAttributeContext is like UpdateStringAttribute but cancels the request when ctx is done.
+func (srv *Database) UpdateStringAttributeContext(ctx context.Context, databaseId, collectionId, key string, required bool, Default *string) (*Attribute, error) {
+	params := map[string]interface{}{
+		"required": required,
+		"default":  Default,
+	}
+	return srv.updateAttribute(ctx, databaseId, collectionId, "string", key, params)
+}
+
+// UpdateIntegerAttribute update the required flag, bounds and default value
+// of an integer attribute.
+func (srv *Database) UpdateIntegerAttribute(databaseId, collectionId, key string, required bool, Min int64, Max int64, Default *int64) (*Attribute, error) {
+	return srv.UpdateIntegerAttributeContext(context.Background(), databaseId, collectionId, key, required, Min, Max, Default)
+}
+
+// UpdateIntegerAttributeContext is like UpdateIntegerAttribute but cancels the request when ctx is done.
+func (srv *Database) UpdateIntegerAttributeContext(ctx context.Context, databaseId, collectionId, key string, required bool, Min int64, Max int64, Default *int64) (*Attribute, error) {
+	params := map[string]interface{}{
+		"required": required,
+		"min":      Min,
+		"max":      Max,
+		"default":  Default,
+	}
+	return srv.updateAttribute(ctx, databaseId, collectionId, "integer", key, params)
+}
+
+// UpdateFloatAttribute update the required flag, bounds and default value of
+// a float attribute.
+func (srv *Database) UpdateFloatAttribute(databaseId, collectionId, key string, required bool, Min float64, Max float64, Default *float64) (*Attribute, error) {
+	return srv.UpdateFloatAttributeContext(context.Background(), databaseId, collectionId, key, required, Min, Max, Default)
+}
+
+// UpdateFloatAttributeContext is like UpdateFloatAttribute but cancels the request when ctx is done.
+func (srv *Database) UpdateFloatAttributeContext(ctx context.Context, databaseId, collectionId, key string, required bool, Min float64, Max float64, Default *float64) (*Attribute, error) {
+	params := map[string]interface{}{
+		"required": required,
+		"min":      Min,
+		"max":      Max,
+		"default":  Default,
+	}
+	return srv.updateAttribute(ctx, databaseId, collectionId, "float", key, params)
+}
+
+// UpdateBooleanAttribute update the required flag and default value of a
+// boolean attribute.
+func (srv *Database) UpdateBooleanAttribute(databaseId, collectionId, key string, required bool, Default *bool) (*Attribute, error) {
+	return srv.UpdateBooleanAttributeContext(context.Background(), databaseId, collectionId, key, required, Default)
+}
+
+// UpdateBooleanAttributeContext is like UpdateBooleanAttribute but cancels the request when ctx is done.
+func (srv *Database) UpdateBooleanAttributeContext(ctx context.Context, databaseId, collectionId, key string, required bool, Default *bool) (*Attribute, error) {
+	params := map[string]interface{}{
+		"required": required,
+		"default":  Default,
+	}
+	return srv.updateAttribute(ctx, databaseId, collectionId, "boolean", key, params)
+}
+
+// UpdateEmailAttribute update the required flag and default value of an
+// email attribute.
+func (srv *Database) UpdateEmailAttribute(databaseId, collectionId, key string, required bool, Default *string) (*Attribute, error) {
+	return srv.UpdateEmailAttributeContext(context.Background(), databaseId, collectionId, key, required, Default)
+}
+
+// UpdateEmailAttributeContext is like UpdateEmailAttribute but cancels the request when ctx is done.
+func (srv *Database) UpdateEmailAttributeContext(ctx context.Context, databaseId, collectionId, key string, required bool, Default *string) (*Attribute, error) {
+	params := map[string]interface{}{
+		"required": required,
+		"default":  Default,
+	}
+	return srv.updateAttribute(ctx, databaseId, collectionId, "email", key, params)
+}
+
+// UpdateEnumAttribute update the elements, required flag and default value of
+// an enum attribute.
+func (srv *Database) UpdateEnumAttribute(databaseId, collectionId, key string, elements []string, required bool, Default *string) (*Attribute, error) {
+	return srv.UpdateEnumAttributeContext(context.Background(), databaseId, collectionId, key, elements, required, Default)
+}
+
+// UpdateEnumAttributeContext is like UpdateEnumAttribute but cancels the request when ctx is done.
+func (srv *Database) UpdateEnumAttributeContext(ctx context.Context, databaseId, collectionId, key string, elements []string, required bool, Default *string) (*Attribute, error) {
+	params := map[string]interface{}{
+		"elements": elements,
+		"required": required,
+		"default":  Default,
+	}
+	return srv.updateAttribute(ctx, databaseId, collectionId, "enum", key, params)
+}
+
+// UpdateIPAttribute update the required flag and default value of an IP
+// address attribute.
+func (srv *Database) UpdateIPAttribute(databaseId, collectionId, key string, required bool, Default *string) (*Attribute, error) {
+	return srv.UpdateIPAttributeContext(context.Background(), databaseId, collectionId, key, required, Default)
+}
+
+// UpdateIPAttributeContext is like UpdateIPAttribute but cancels the request when ctx is done.
+func (srv *Database) UpdateIPAttributeContext(ctx context.Context, databaseId, collectionId, key string, required bool, Default *string) (*Attribute, error) {
+	params := map[string]interface{}{
+		"required": required,
+		"default":  Default,
+	}
+	return srv.updateAttribute(ctx, databaseId, collectionId, "ip", key, params)
+}
+
+// UpdateURLAttribute update the required flag and default value of a URL
+// attribute.
+func (srv *Database) UpdateURLAttribute(databaseId, collectionId, key string, required bool, Default *string) (*Attribute, error) {
+	return srv.UpdateURLAttributeContext(context.Background(), databaseId, collectionId, key, required, Default)
+}
+
+// UpdateURLAttributeContext is like UpdateURLAttribute but cancels the request when ctx is done.
+func (srv *Database) UpdateURLAttributeContext(ctx context.Context, databaseId, collectionId, key string, required bool, Default *string) (*Attribute, error) {
+	params := map[string]interface{}{
+		"required": required,
+		"default":  Default,
+	}
+	return srv.updateAttribute(ctx, databaseId, collectionId, "url", key, params)
+}
+
+// UpdateDatetimeAttribute update the required flag and default value of a
+// datetime attribute.
+func (srv *Database) UpdateDatetimeAttribute(databaseId, collectionId, key string, required bool, Default *string) (*Attribute, error) {
+	return srv.UpdateDatetimeAttributeContext(context.Background(), databaseId, collectionId, key, required, Default)
+}
+
+// UpdateDatetimeAttributeContext is like UpdateDatetimeAttribute but cancels the request when ctx is done.
+func (srv *Database) UpdateDatetimeAttributeContext(ctx context.Context, databaseId, collectionId, key string, required bool, Default *string) (*Attribute, error) {
+	params := map[string]interface{}{
+		"required": required,
+		"default":  Default,
+	}
+	return srv.updateAttribute(ctx, databaseId, collectionId, "datetime", key, params)
+}
+
+// createAttribute posts params to the create endpoint of the given attribute
+// kind
+func (srv *Database) createAttribute(ctx context.Context, databaseId, collectionId, kind string, params map[string]interface{}) (*Attribute, error) {
+	r := pathReplacer("{databaseId}", databaseId, "{collectionId}", collectionId, "{kind}", kind)
+	path := r.Replace("/databases/{databaseId}/collections/{collectionId}/attributes/{kind}")
+
+	resp, err := srv.Client.CallAPIContext(ctx, "POST", path, srv.Client.headers, params)
+	if err != nil {
+		return nil, err
+	}
+	var result Attribute
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// updateAttribute patches the attribute key through the update endpoint of
+// the given attribute kind
+func (srv *Database) updateAttribute(ctx context.Context, databaseId, collectionId, kind, key string, params map[string]interface{}) (*Attribute, error) {
+	r := pathReplacer("{databaseId}", databaseId, "{collectionId}", collectionId, "{kind}", kind, "{key}", key)
+	path := r.Replace("/databases/{databaseId}/collections/{collectionId}/attributes/{kind}/{key}")
+
+	resp, err := srv.Client.CallAPIContext(ctx, "PATCH", path, srv.Client.headers, params)
+	if err != nil {
+		return nil, err
+	}
+	var result Attribute
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
@@ -1,20 +1,18 @@
 package appwrite
 
-import (
-	"strings"
-)
+import "context"
 
 // Avatars service
 type Avatars struct {
 	Client Client
 }
 
-func NewAvatars(clt Client) Avatars {  
-    service := Avatars{
+func NewAvatars(clt Client) Avatars {
+	service := Avatars{
 		Client: clt,
 	}
 
-    return service
+	return service
 }
 
 // GetBrowser you can use this endpoint to show different browser icons to
@@ -22,16 +20,21 @@ func NewAvatars(clt Client) Avatars {
 // your user /account/sessions endpoint. Use width, height and quality
 // arguments to change the output settings.
 func (srv *Avatars) GetBrowser(Code string, Width int, Height int, Quality int) (map[string]interface{}, error) {
-	r := strings.NewReplacer("{code}", Code)
+	return srv.GetBrowserContext(context.Background(), Code, Width, Height, Quality)
+}
+
+// GetBrowserContext is like GetBrowser but cancels the request when ctx is done.
+func (srv *Avatars) GetBrowserContext(ctx context.Context, Code string, Width int, Height int, Quality int) (map[string]interface{}, error) {
+	r := pathReplacer("{code}", Code)
 	path := r.Replace("/avatars/browsers/{code}")
 
 	params := map[string]interface{}{
-		"width": Width,
-		"height": Height,
+		"width":   Width,
+		"height":  Height,
 		"quality": Quality,
 	}
 
-	return srv.Client.Call("GET", path, nil, params)
+	return srv.Client.CallContext(ctx, "GET", path, nil, params)
 }
 
 // GetCreditCard need to display your users with your billing method or their
@@ -39,44 +42,59 @@ func (srv *Avatars) GetBrowser(Code string, Width int, Height int, Quality int)
 // credit card provider you need. Use width, height and quality arguments to
 // change the output settings.
 func (srv *Avatars) GetCreditCard(Code string, Width int, Height int, Quality int) (map[string]interface{}, error) {
-	r := strings.NewReplacer("{code}", Code)
+	return srv.GetCreditCardContext(context.Background(), Code, Width, Height, Quality)
+}
+
+// GetCreditCardContext is like GetCreditCard but cancels the request when ctx is done.
+func (srv *Avatars) GetCreditCardContext(ctx context.Context, Code string, Width int, Height int, Quality int) (map[string]interface{}, error) {
+	r := pathReplacer("{code}", Code)
 	path := r.Replace("/avatars/credit-cards/{code}")
 
 	params := map[string]interface{}{
-		"width": Width,
-		"height": Height,
+		"width":   Width,
+		"height":  Height,
 		"quality": Quality,
 	}
 
-	return srv.Client.Call("GET", path, nil, params)
+	return srv.Client.CallContext(ctx, "GET", path, nil, params)
 }
 
-// GetFavicon use this endpoint to fetch the favorite icon (AKA favicon) of a 
+// GetFavicon use this endpoint to fetch the favorite icon (AKA favicon) of a
 // any remote website URL.
 func (srv *Avatars) GetFavicon(Url string) (map[string]interface{}, error) {
+	return srv.GetFaviconContext(context.Background(), Url)
+}
+
+// GetFaviconContext is like GetFavicon but cancels the request when ctx is done.
+func (srv *Avatars) GetFaviconContext(ctx context.Context, Url string) (map[string]interface{}, error) {
 	path := "/avatars/favicon"
 
 	params := map[string]interface{}{
 		"url": Url,
 	}
 
-	return srv.Client.Call("GET", path, nil, params)
+	return srv.Client.CallContext(ctx, "GET", path, nil, params)
 }
 
 // GetFlag you can use this endpoint to show different country flags icons to
 // your users. The code argument receives the 2 letter country code. Use
 // width, height and quality arguments to change the output settings.
 func (srv *Avatars) GetFlag(Code string, Width int, Height int, Quality int) (map[string]interface{}, error) {
-	r := strings.NewReplacer("{code}", Code)
+	return srv.GetFlagContext(context.Background(), Code, Width, Height, Quality)
+}
+
+// GetFlagContext is like GetFlag but cancels the request when ctx is done.
+func (srv *Avatars) GetFlagContext(ctx context.Context, Code string, Width int, Height int, Quality int) (map[string]interface{}, error) {
+	r := pathReplacer("{code}", Code)
 	path := r.Replace("/avatars/flags/{code}")
 
 	params := map[string]interface{}{
-		"width": Width,
-		"height": Height,
+		"width":   Width,
+		"height":  Height,
 		"quality": Quality,
 	}
 
-	return srv.Client.Call("GET", path, nil, params)
+	return srv.Client.CallContext(ctx, "GET", path, nil, params)
 }
 
 // GetImage use this endpoint to fetch a remote image URL and crop it to any
@@ -84,28 +102,38 @@ func (srv *Avatars) GetFlag(Code string, Width int, Height int, Quality int) (ma
 // display remote images in your app or in case you want to make sure a 3rd
 // party image is properly served using a TLS protocol.
 func (srv *Avatars) GetImage(Url string, Width int, Height int) (map[string]interface{}, error) {
+	return srv.GetImageContext(context.Background(), Url, Width, Height)
+}
+
+// GetImageContext is like GetImage but cancels the request when ctx is done.
+func (srv *Avatars) GetImageContext(ctx context.Context, Url string, Width int, Height int) (map[string]interface{}, error) {
 	path := "/avatars/image"
 
 	params := map[string]interface{}{
-		"url": Url,
-		"width": Width,
+		"url":    Url,
+		"width":  Width,
 		"height": Height,
 	}
 
-	return srv.Client.Call("GET", path, nil, params)
+	return srv.Client.CallContext(ctx, "GET", path, nil, params)
 }
 
 // GetQR converts a given plain text to a QR code image. You can use the query
 // parameters to change the size and style of the resulting image.
 func (srv *Avatars) GetQR(Text string, Size int, Margin int, Download int) (map[string]interface{}, error) {
+	return srv.GetQRContext(context.Background(), Text, Size, Margin, Download)
+}
+
+// GetQRContext is like GetQR but cancels the request when ctx is done.
+func (srv *Avatars) GetQRContext(ctx context.Context, Text string, Size int, Margin int, Download int) (map[string]interface{}, error) {
 	path := "/avatars/qr"
 
 	params := map[string]interface{}{
-		"text": Text,
-		"size": Size,
-		"margin": Margin,
+		"text":     Text,
+		"size":     Size,
+		"margin":   Margin,
 		"download": Download,
 	}
 
-	return srv.Client.Call("GET", path, nil, params)
+	return srv.Client.CallContext(ctx, "GET", path, nil, params)
 }
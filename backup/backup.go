@@ -0,0 +1,272 @@
+// Package backup snapshots the databases, buckets and function variables of
+// an Appwrite project into a tar archive and restores them into another
+// project.
+//
+// The archive starts with a manifest.json describing its content, followed
+// by the schema of the databases as a schema.Spec, the documents of every
+// collection as JSONL and the content of every file:
+//
+//	manifest.json
+//	schema.json
+//	documents/<databaseId>/<collectionId>.jsonl
+//	files/<bucketId>/<fileId>
+//
+// Entries are written in the order Restore needs them, so that archives can
+// be restored while being streamed.
+package backup
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"time"
+
+	appwrite "github.com/appwrite/sdk-for-go"
+	"github.com/appwrite/sdk-for-go/schema"
+)
+
+// Version is the version of the archive format written by Backup
+const Version = 1
+
+// Entries of the archive
+const (
+	manifestPath  = "manifest.json"
+	schemaPath    = "schema.json"
+	documentsPath = "documents"
+	filesPath     = "files"
+)
+
+// Project holds the services of the project to back up or restore. Parts of
+// the project whose service is nil are skipped.
+type Project struct {
+	Database  *appwrite.Database
+	Storage   *appwrite.Storage
+	Functions *appwrite.Function
+}
+
+// Manifest describes the content of an archive
+type Manifest struct {
+	Version     int               `json:"version"`
+	CreatedAt   string            `json:"createdAt"`
+	Collections []CollectionEntry `json:"collections"`
+	Buckets     []BucketEntry     `json:"buckets"`
+	Functions   []FunctionEntry   `json:"functions"`
+}
+
+// CollectionEntry points to the documents of a collection
+type CollectionEntry struct {
+	DatabaseId   string `json:"databaseId"`
+	CollectionId string `json:"collectionId"`
+	Documents    int    `json:"documents"`
+	Path         string `json:"path"`
+}
+
+// BucketEntry holds the metadata of a bucket and of its files
+type BucketEntry struct {
+	Bucket appwrite.Bucket `json:"bucket"`
+	Files  []FileEntry     `json:"files"`
+}
+
+// FileEntry holds the metadata of a file and points to its content
+type FileEntry struct {
+	File appwrite.File `json:"file"`
+	Path string        `json:"path"`
+}
+
+// FunctionEntry holds the variables of a function
+type FunctionEntry struct {
+	Id        string              `json:"id"`
+	Name      string              `json:"name"`
+	Variables []appwrite.Variable `json:"variables"`
+}
+
+// Backup writes a snapshot of the project to w as a tar archive and returns
+// its manifest. Documents are exported to temporary files first, as the
+// size of every entry must be known before it is written. The files are
+// closed between the export and the archive so that projects with many
+// collections don't run out of file descriptors.
+func Backup(ctx context.Context, project Project, w io.Writer) (*Manifest, error) {
+	manifest := &Manifest{
+		Version:     Version,
+		CreatedAt:   time.Now().UTC().Format(time.RFC3339),
+		Collections: []CollectionEntry{},
+		Buckets:     []BucketEntry{},
+		Functions:   []FunctionEntry{},
+	}
+
+	var spec *schema.Spec
+	var exports []string
+	defer func() {
+		for _, name := range exports {
+			os.Remove(name)
+		}
+	}()
+
+	if project.Database != nil {
+		var err error
+		if spec, err = schema.Export(ctx, project.Database); err != nil {
+			return nil, fmt.Errorf("backup: exporting schema: %w", err)
+		}
+		for _, database := range spec.Databases {
+			for _, collection := range database.Collections {
+				f, err := os.CreateTemp("", "appwrite-backup-*.jsonl")
+				if err != nil {
+					return nil, err
+				}
+				exports = append(exports, f.Name())
+
+				n, err := project.Database.ExportDocuments(ctx, database.Id, collection.Id, f, appwrite.FormatJSONL)
+				if closeErr := f.Close(); err == nil {
+					err = closeErr
+				}
+				if err != nil {
+					return nil, fmt.Errorf("backup: exporting documents of %s/%s: %w", database.Id, collection.Id, err)
+				}
+				manifest.Collections = append(manifest.Collections, CollectionEntry{
+					DatabaseId:   database.Id,
+					CollectionId: collection.Id,
+					Documents:    n,
+					Path:         path.Join(documentsPath, database.Id, collection.Id+".jsonl"),
+				})
+			}
+		}
+	}
+
+	if project.Storage != nil {
+		for bucket, err := range project.Storage.AllBuckets(ctx, nil, 100) {
+			if err != nil {
+				return nil, fmt.Errorf("backup: listing buckets: %w", err)
+			}
+			entry := BucketEntry{Bucket: bucket, Files: []FileEntry{}}
+			for file, err := range project.Storage.AllFiles(ctx, bucket.Id, nil, 100) {
+				if err != nil {
+					return nil, fmt.Errorf("backup: listing files of %s: %w", bucket.Id, err)
+				}
+				if file.BucketId == "" {
+					file.BucketId = bucket.Id
+				}
+				entry.Files = append(entry.Files, FileEntry{
+					File: file,
+					Path: path.Join(filesPath, bucket.Id, file.Id),
+				})
+			}
+			manifest.Buckets = append(manifest.Buckets, entry)
+		}
+	}
+
+	if project.Functions != nil {
+		for function, err := range project.Functions.AllFunctions(ctx, nil, 100) {
+			if err != nil {
+				return nil, fmt.Errorf("backup: listing functions: %w", err)
+			}
+			entry := FunctionEntry{Id: function.Id, Name: function.Name}
+			for variable, err := range project.Functions.AllVariables(ctx, function.Id, nil, 100) {
+				if err != nil {
+					return nil, fmt.Errorf("backup: listing variables of %s: %w", function.Id, err)
+				}
+				entry.Variables = append(entry.Variables, variable)
+			}
+			manifest.Functions = append(manifest.Functions, entry)
+		}
+	}
+
+	tw := tar.NewWriter(w)
+	if err := writeJSON(tw, manifestPath, manifest); err != nil {
+		return nil, err
+	}
+	if spec != nil {
+		if err := writeJSON(tw, schemaPath, spec); err != nil {
+			return nil, err
+		}
+	}
+	for i, entry := range manifest.Collections {
+		if err := writeExport(tw, entry.Path, exports[i]); err != nil {
+			return nil, err
+		}
+	}
+	for _, bucket := range manifest.Buckets {
+		for _, file := range bucket.Files {
+			if err := downloadFile(ctx, tw, project.Storage, file); err != nil {
+				return nil, fmt.Errorf("backup: downloading %s: %w", file.Path, err)
+			}
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// downloadFile writes the content of a file to the archive, going through a
+// temporary file when the server doesn't give its length
+func downloadFile(ctx context.Context, tw *tar.Writer, storage *appwrite.Storage, file FileEntry) error {
+	content, err := storage.GetFileDownloadContext(ctx, file.File.BucketId, file.File.Id)
+	if err != nil {
+		return err
+	}
+	defer content.Close()
+
+	if content.ContentLength >= 0 {
+		return writeEntry(tw, file.Path, content.ContentLength, content)
+	}
+
+	f, err := os.CreateTemp("", "appwrite-backup-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+	if _, err := io.Copy(f, content); err != nil {
+		return err
+	}
+	return writeFile(tw, file.Path, f)
+}
+
+// writeExport writes the content of a temporary file to the archive
+func writeExport(tw *tar.Writer, name, tmp string) error {
+	f, err := os.Open(tmp)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return writeFile(tw, name, f)
+}
+
+// writeFile writes the content of a file to the archive from its start
+func writeFile(tw *tar.Writer, name string, f *os.File) error {
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	return writeEntry(tw, name, info.Size(), f)
+}
+
+func writeJSON(tw *tar.Writer, name string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeEntry(tw, name, int64(len(data)), bytes.NewReader(data))
+}
+
+func writeEntry(tw *tar.Writer, name string, size int64, r io.Reader) error {
+	header := &tar.Header{
+		Name:    name,
+		Mode:    0o644,
+		Size:    size,
+		ModTime: time.Now(),
+	}
+	if err := tw.WriteHeader(header); err != nil {
+		return err
+	}
+	_, err := io.Copy(tw, r)
+	return err
+}
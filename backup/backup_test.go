@@ -0,0 +1,692 @@
+package backup
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	appwrite "github.com/appwrite/sdk-for-go"
+)
+
+const bigInt = "9007199254740993" // 2^53 + 1, not representable as a float64
+
+// fakeProject is an in-memory project serving the endpoints Backup and
+// Restore use. Like the server, it rejects documents pointing to related
+// documents that don't exist.
+type fakeProject struct {
+	t  *testing.T
+	mu sync.Mutex
+
+	databases   []appwrite.DatabaseObject
+	collections map[string][]*appwrite.Collection
+	// documents are keyed by "databaseId/collectionId"
+	documents map[string][]map[string]interface{}
+	buckets   []*appwrite.Bucket
+	files     map[string][]*appwrite.File
+	// content is keyed by "bucketId/fileId"
+	content   map[string][]byte
+	functions []appwrite.FunctionObject
+	variables map[string][]*appwrite.Variable
+
+	// writes lists the requests changing buckets, files and variables, with
+	// the Content-Range of file chunks
+	writes []string
+}
+
+func newFakeProject(t *testing.T) *fakeProject {
+	return &fakeProject{
+		t:           t,
+		collections: map[string][]*appwrite.Collection{},
+		documents:   map[string][]map[string]interface{}{},
+		files:       map[string][]*appwrite.File{},
+		content:     map[string][]byte{},
+		variables:   map[string][]*appwrite.Variable{},
+	}
+}
+
+// serve starts serving the project and returns its services
+func (p *fakeProject) serve() Project {
+	srv := httptest.NewServer(p)
+	p.t.Cleanup(srv.Close)
+
+	client := appwrite.NewClient()
+	client.SetEndpoint(srv.URL)
+	db := appwrite.NewDatabase(client)
+	storage := appwrite.NewStorage(client)
+	functions := appwrite.NewFunctions(client)
+	return Project{Database: &db, Storage: &storage, Functions: &functions}
+}
+
+func (p *fakeProject) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	var ids []string
+	route := func(method string, pattern ...string) bool {
+		if r.Method != method || len(parts) != len(pattern) {
+			return false
+		}
+		ids = ids[:0]
+		for i, part := range pattern {
+			if part == "*" {
+				ids = append(ids, parts[i])
+			} else if part != parts[i] {
+				return false
+			}
+		}
+		return true
+	}
+
+	var params map[string]interface{}
+	if r.Header.Get("Content-Type") == "application/json" {
+		dec := json.NewDecoder(r.Body)
+		dec.UseNumber()
+		if err := dec.Decode(&params); err != nil {
+			fail(w, http.StatusBadRequest, "general_argument_invalid", err.Error())
+			return
+		}
+	}
+	if parts[0] != "databases" && r.Method != http.MethodGet {
+		write := r.Method + " " + r.URL.Path
+		if contentRange := r.Header.Get("Content-Range"); contentRange != "" {
+			write += " " + contentRange
+		}
+		p.writes = append(p.writes, write)
+	}
+
+	switch {
+	case route("GET", "databases"):
+		reply(w, appwrite.DatabaseList{Total: int64(len(p.databases)), Databases: page(r, p.databases, func(d appwrite.DatabaseObject) string { return d.Id })})
+	case route("POST", "databases"):
+		databaseId := str(params["databaseId"])
+		if p.database(databaseId) != nil {
+			fail(w, http.StatusConflict, "database_already_exists", "Database already exists")
+			return
+		}
+		database := appwrite.DatabaseObject{Id: databaseId, Name: str(params["name"]), Enabled: params["enabled"] == true}
+		p.databases = append(p.databases, database)
+		reply(w, database)
+	case route("GET", "databases", "*"):
+		database := p.database(ids[0])
+		if database == nil {
+			fail(w, http.StatusNotFound, "database_not_found", "Database not found")
+			return
+		}
+		reply(w, database)
+
+	case route("GET", "databases", "*", "collections"):
+		var collections []appwrite.Collection
+		for _, collection := range p.collections[ids[0]] {
+			collections = append(collections, *collection)
+		}
+		reply(w, appwrite.CollectionList{Total: len(collections), Collections: page(r, collections, func(c appwrite.Collection) string { return c.Id })})
+	case route("POST", "databases", "*", "collections"):
+		if p.database(ids[0]) == nil {
+			fail(w, http.StatusNotFound, "database_not_found", "Database not found")
+			return
+		}
+		collection := &appwrite.Collection{
+			Id:               str(params["collectionId"]),
+			Name:             str(params["name"]),
+			DatabaseId:       ids[0],
+			Permissions:      strs(params["permissions"]),
+			Enabled:          true,
+			DocumentSecurity: params["documentSecurity"] == true,
+			Attributes:       []appwrite.Attribute{},
+			Indexes:          []appwrite.Index{},
+		}
+		p.collections[ids[0]] = append(p.collections[ids[0]], collection)
+		reply(w, collection)
+	case route("GET", "databases", "*", "collections", "*"):
+		collection := p.collection(ids[0], ids[1])
+		if collection == nil {
+			fail(w, http.StatusNotFound, "collection_not_found", "Collection not found")
+			return
+		}
+		reply(w, collection)
+	case route("POST", "databases", "*", "collections", "*", "attributes", "*"):
+		collection := p.collection(ids[0], ids[1])
+		if collection == nil {
+			fail(w, http.StatusNotFound, "collection_not_found", "Collection not found")
+			return
+		}
+		attribute := appwrite.Attribute{AttributeOptions: appwrite.AttributeOptions{
+			Key:      str(params["key"]),
+			Type:     ids[2],
+			Status:   appwrite.StatusAvailable,
+			Required: params["required"] == true,
+			Array:    params["array"] == true,
+			Default:  params["default"],
+			Min:      number(params["min"]),
+			Max:      number(params["max"]),
+		}}
+		if size, err := number(params["size"]).Int64(); err == nil {
+			attribute.Size = int(size)
+		}
+		if ids[2] == appwrite.AttributeTypeRelationship {
+			related := str(params["relatedCollectionId"])
+			if p.collection(ids[0], related) == nil {
+				fail(w, http.StatusNotFound, "collection_not_found", "Related collection not found")
+				return
+			}
+			if attribute.Key == "" {
+				attribute.Key = related
+			}
+			attribute.RelatedCollection = related
+			attribute.RelationType = str(params["type"])
+			attribute.TwoWay = params["twoWay"] == true
+			attribute.OnDelete = str(params["onDelete"])
+			attribute.Side = "parent"
+		}
+		collection.Attributes = append(collection.Attributes, attribute)
+		reply(w, attribute)
+	case route("POST", "databases", "*", "collections", "*", "indexes"):
+		collection := p.collection(ids[0], ids[1])
+		if collection == nil {
+			fail(w, http.StatusNotFound, "collection_not_found", "Collection not found")
+			return
+		}
+		index := appwrite.Index{
+			Key:        str(params["key"]),
+			Type:       appwrite.IndexType(str(params["type"])),
+			Status:     appwrite.StatusAvailable,
+			Attributes: strs(params["attributes"]),
+		}
+		collection.Indexes = append(collection.Indexes, index)
+		reply(w, index)
+
+	case route("GET", "databases", "*", "collections", "*", "documents"):
+		documents := p.documents[ids[0]+"/"+ids[1]]
+		reply(w, map[string]interface{}{
+			"total":     len(documents),
+			"documents": page(r, documents, func(doc map[string]interface{}) string { return str(doc["$id"]) }),
+		})
+	case route("POST", "databases", "*", "collections", "*", "documents"):
+		key := ids[0] + "/" + ids[1]
+		documentId := str(params["documentId"])
+		if p.document(key, documentId) != nil {
+			fail(w, http.StatusConflict, "document_already_exists", "Document with the requested ID already exists.")
+			return
+		}
+		data, _ := params["data"].(map[string]interface{})
+		if err := p.checkRelationships(ids[0], ids[1], data); err != nil {
+			fail(w, http.StatusBadRequest, "relationship_value_invalid", err.Error())
+			return
+		}
+		doc := map[string]interface{}{"$id": documentId, "$permissions": strs(params["permissions"])}
+		for attribute, value := range data {
+			doc[attribute] = value
+		}
+		p.documents[key] = append(p.documents[key], doc)
+		reply(w, doc)
+	case route("PATCH", "databases", "*", "collections", "*", "documents", "*"):
+		doc := p.document(ids[0]+"/"+ids[1], ids[2])
+		if doc == nil {
+			fail(w, http.StatusNotFound, "document_not_found", "Document not found")
+			return
+		}
+		data, _ := params["data"].(map[string]interface{})
+		if err := p.checkRelationships(ids[0], ids[1], data); err != nil {
+			fail(w, http.StatusBadRequest, "relationship_value_invalid", err.Error())
+			return
+		}
+		for attribute, value := range data {
+			doc[attribute] = value
+		}
+		reply(w, doc)
+
+	case route("GET", "storage", "buckets"):
+		var buckets []appwrite.Bucket
+		for _, bucket := range p.buckets {
+			buckets = append(buckets, *bucket)
+		}
+		reply(w, appwrite.BucketListResponse{Total: len(buckets), Buckets: page(r, buckets, func(b appwrite.Bucket) string { return b.Id })})
+	case route("POST", "storage", "buckets"):
+		if p.bucket(str(params["bucketId"])) != nil {
+			fail(w, http.StatusConflict, "storage_bucket_already_exists", "Bucket already exists")
+			return
+		}
+		bucket := &appwrite.Bucket{}
+		convert(params, bucket)
+		bucket.Id = str(params["bucketId"])
+		p.buckets = append(p.buckets, bucket)
+		reply(w, bucket)
+	case route("PUT", "storage", "buckets", "*"):
+		bucket := p.bucket(ids[0])
+		if bucket == nil {
+			fail(w, http.StatusNotFound, "storage_bucket_not_found", "Bucket not found")
+			return
+		}
+		convert(params, bucket)
+		reply(w, bucket)
+	case route("GET", "storage", "buckets", "*", "files"):
+		var files []appwrite.File
+		for _, file := range p.files[ids[0]] {
+			files = append(files, *file)
+		}
+		reply(w, appwrite.FileListResponse{Total: len(files), Files: page(r, files, func(f appwrite.File) string { return f.Id })})
+	case route("GET", "storage", "buckets", "*", "files", "*"):
+		file := p.file(ids[0], ids[1])
+		if file == nil {
+			fail(w, http.StatusNotFound, "storage_file_not_found", "File not found")
+			return
+		}
+		reply(w, file)
+	case route("GET", "storage", "buckets", "*", "files", "*", "download"):
+		if p.file(ids[0], ids[1]) == nil {
+			fail(w, http.StatusNotFound, "storage_file_not_found", "File not found")
+			return
+		}
+		content := p.content[ids[0]+"/"+ids[1]]
+		w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+		w.Write(content)
+	case route("POST", "storage", "buckets", "*", "files"):
+		p.upload(w, r, ids[0])
+
+	case route("GET", "functions"):
+		reply(w, appwrite.FunctionListResponse{Total: len(p.functions), Functions: page(r, p.functions, func(f appwrite.FunctionObject) string { return f.Id })})
+	case route("GET", "functions", "*", "variables"):
+		var variables []appwrite.Variable
+		for _, variable := range p.variables[ids[0]] {
+			variables = append(variables, *variable)
+		}
+		reply(w, appwrite.VariableListResponse{Total: len(variables), Variables: page(r, variables, func(v appwrite.Variable) string { return v.Id })})
+	case route("POST", "functions", "*", "variables"):
+		for _, variable := range p.variables[ids[0]] {
+			if variable.Key == str(params["key"]) {
+				fail(w, http.StatusConflict, "variable_already_exists", "Variable with the same ID already exists")
+				return
+			}
+		}
+		variable := &appwrite.Variable{
+			Id:         fmt.Sprintf("var%d", len(p.variables[ids[0]])+1),
+			FunctionId: ids[0],
+			Key:        str(params["key"]),
+			Value:      str(params["value"]),
+		}
+		p.variables[ids[0]] = append(p.variables[ids[0]], variable)
+		reply(w, variable)
+	case route("PUT", "functions", "*", "variables", "*"):
+		for _, variable := range p.variables[ids[0]] {
+			if variable.Id == ids[1] {
+				variable.Key, variable.Value = str(params["key"]), str(params["value"])
+				reply(w, variable)
+				return
+			}
+		}
+		fail(w, http.StatusNotFound, "variable_not_found", "Variable not found")
+
+	default:
+		p.t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		fail(w, http.StatusBadRequest, "general_route_not_found", "unexpected request")
+	}
+}
+
+// upload stores a file or a chunk of a file sent as multipart/form-data
+func (p *fakeProject) upload(w http.ResponseWriter, r *http.Request, bucketId string) {
+	if p.bucket(bucketId) == nil {
+		fail(w, http.StatusNotFound, "storage_bucket_not_found", "Bucket not found")
+		return
+	}
+	if err := r.ParseMultipartForm(appwrite.ChunkSize); err != nil {
+		fail(w, http.StatusBadRequest, "storage_invalid_file", err.Error())
+		return
+	}
+	part, header, err := r.FormFile("file")
+	if err != nil {
+		fail(w, http.StatusBadRequest, "storage_invalid_file", err.Error())
+		return
+	}
+	chunk, _ := io.ReadAll(part)
+
+	fileId := r.FormValue("fileId")
+	var start, end, size int64
+	if contentRange := r.Header.Get("Content-Range"); contentRange != "" {
+		fmt.Sscanf(contentRange, "bytes %d-%d/%d", &start, &end, &size)
+	} else {
+		size = int64(len(chunk))
+	}
+
+	key := bucketId + "/" + fileId
+	file := p.file(bucketId, fileId)
+	if start == 0 {
+		if file != nil {
+			fail(w, http.StatusConflict, "storage_file_already_exists", "A storage file with the requested ID already exists.")
+			return
+		}
+		file = &appwrite.File{
+			Id:           fileId,
+			BucketId:     bucketId,
+			Name:         header.Filename,
+			Permissions:  r.Form["permissions[]"],
+			SizeOriginal: int(size),
+			ChunksTotal:  int((size + appwrite.ChunkSize - 1) / appwrite.ChunkSize),
+		}
+		p.files[bucketId] = append(p.files[bucketId], file)
+	} else if file == nil || r.Header.Get("x-appwrite-id") != fileId || start != int64(len(p.content[key])) {
+		fail(w, http.StatusBadRequest, "storage_invalid_content_range", "Invalid content range")
+		return
+	}
+	p.content[key] = append(p.content[key], chunk...)
+	file.ChunksUploaded++
+	reply(w, file)
+}
+
+func (p *fakeProject) database(databaseId string) *appwrite.DatabaseObject {
+	for i := range p.databases {
+		if p.databases[i].Id == databaseId {
+			return &p.databases[i]
+		}
+	}
+	return nil
+}
+
+func (p *fakeProject) collection(databaseId, collectionId string) *appwrite.Collection {
+	for _, collection := range p.collections[databaseId] {
+		if collection.Id == collectionId {
+			return collection
+		}
+	}
+	return nil
+}
+
+func (p *fakeProject) document(key, documentId string) map[string]interface{} {
+	for _, doc := range p.documents[key] {
+		if doc["$id"] == documentId {
+			return doc
+		}
+	}
+	return nil
+}
+
+func (p *fakeProject) bucket(bucketId string) *appwrite.Bucket {
+	for _, bucket := range p.buckets {
+		if bucket.Id == bucketId {
+			return bucket
+		}
+	}
+	return nil
+}
+
+func (p *fakeProject) file(bucketId, fileId string) *appwrite.File {
+	for _, file := range p.files[bucketId] {
+		if file.Id == fileId {
+			return file
+		}
+	}
+	return nil
+}
+
+// checkRelationships fails when data points to related documents that don't
+// exist
+func (p *fakeProject) checkRelationships(databaseId, collectionId string, data map[string]interface{}) error {
+	for _, attribute := range p.collection(databaseId, collectionId).Attributes {
+		if attribute.Type != appwrite.AttributeTypeRelationship || data[attribute.Key] == nil {
+			continue
+		}
+		relatedId := data[attribute.Key]
+		if related, ok := relatedId.(map[string]interface{}); ok {
+			relatedId = related["$id"]
+		}
+		if p.document(databaseId+"/"+attribute.RelatedCollection, str(relatedId)) == nil {
+			return fmt.Errorf("related document %v of %q not found", relatedId, attribute.Key)
+		}
+	}
+	return nil
+}
+
+// page applies the limit and cursorAfter queries of r to items
+func page[T any](r *http.Request, items []T, idOf func(T) string) []T {
+	limit := len(items)
+	for _, q := range r.URL.Query()["queries[]"] {
+		fmt.Sscanf(q, "limit(%d)", &limit)
+		if cursor, ok := strings.CutPrefix(q, "cursorAfter("); ok {
+			cursor, _ = strconv.Unquote(strings.TrimSuffix(cursor, ")"))
+			for i, item := range items {
+				if idOf(item) == cursor {
+					items = items[i+1:]
+					break
+				}
+			}
+		}
+	}
+	return items[:min(limit, len(items))]
+}
+
+func reply(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+func fail(w http.ResponseWriter, code int, typ, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(map[string]interface{}{"message": message, "code": code, "type": typ})
+}
+
+// convert decodes params into v through JSON
+func convert(params map[string]interface{}, v interface{}) {
+	data, _ := json.Marshal(params)
+	json.Unmarshal(data, v)
+}
+
+func str(v interface{}) string {
+	s, _ := v.(string)
+	return s
+}
+
+func strs(v interface{}) []string {
+	list := []string{}
+	values, _ := v.([]interface{})
+	for _, value := range values {
+		list = append(list, str(value))
+	}
+	return list
+}
+
+func number(v interface{}) json.Number {
+	n, _ := v.(json.Number)
+	return n
+}
+
+// source returns a project with two collections related to each other, a
+// bucket holding a file uploaded in a single request and one uploaded in
+// chunks, and a function with variables
+func source(t *testing.T) *fakeProject {
+	p := newFakeProject(t)
+	p.databases = []appwrite.DatabaseObject{{Id: "main", Name: "Main", Enabled: true}}
+	attribute := func(key, typ string) appwrite.Attribute {
+		return appwrite.Attribute{AttributeOptions: appwrite.AttributeOptions{Key: key, Type: typ, Status: appwrite.StatusAvailable}}
+	}
+	title := attribute("title", appwrite.AttributeTypeString)
+	title.Size = 128
+	views := attribute("views", appwrite.AttributeTypeInteger)
+	views.Default = json.Number(bigInt)
+	author := attribute("author", appwrite.AttributeTypeRelationship)
+	author.RelatedCollection, author.RelationType, author.OnDelete, author.Side = "users", "manyToOne", appwrite.OnDeleteSetNull, "parent"
+	name := attribute("name", appwrite.AttributeTypeString)
+	name.Size = 64
+
+	p.collections["main"] = []*appwrite.Collection{
+		{Id: "posts", Name: "Posts", DatabaseId: "main", Enabled: true, Attributes: []appwrite.Attribute{title, views, author}, Indexes: []appwrite.Index{
+			{Key: "by_title", Type: "key", Status: appwrite.StatusAvailable, Attributes: []string{"title"}},
+		}},
+		{Id: "users", Name: "Users", DatabaseId: "main", Enabled: true, Attributes: []appwrite.Attribute{name}},
+	}
+	// Related documents are served nested, as the server does
+	p.documents["main/posts"] = []map[string]interface{}{
+		{"$id": "p1", "$permissions": []string{}, "title": "First", "views": json.Number(bigInt), "author": map[string]interface{}{"$id": "u1", "$collectionId": "users", "name": "Ann"}},
+		{"$id": "p2", "$permissions": []string{}, "title": "Second", "views": json.Number("2"), "author": nil},
+		{"$id": "p3", "$permissions": []string{}, "title": "Third", "views": json.Number("3"), "author": map[string]interface{}{"$id": "u3", "$collectionId": "users", "name": "Gone"}},
+	}
+	p.documents["main/users"] = []map[string]interface{}{
+		{"$id": "u1", "$permissions": []string{}, "name": "Ann"},
+		{"$id": "u2", "$permissions": []string{}, "name": "Bob"},
+	}
+
+	p.buckets = []*appwrite.Bucket{{Id: "media", Name: "Media", Enabled: true, MaximumFileSize: 2 * appwrite.ChunkSize}}
+	large := bytes.Repeat([]byte("0123456789"), appwrite.ChunkSize/10+1)
+	p.files["media"] = []*appwrite.File{
+		{Id: "large", BucketId: "media", Name: "large.bin", SizeOriginal: len(large), ChunksTotal: 2, ChunksUploaded: 2},
+		{Id: "small", BucketId: "media", Name: "small.txt", SizeOriginal: 5, ChunksTotal: 1, ChunksUploaded: 1},
+	}
+	p.content["media/large"] = large
+	p.content["media/small"] = []byte("small")
+
+	p.functions = []appwrite.FunctionObject{{Id: "fn", Name: "Function"}}
+	p.variables["fn"] = []*appwrite.Variable{
+		{Id: "v1", FunctionId: "fn", Key: "A", Value: "1"},
+		{Id: "v2", FunctionId: "fn", Key: "B", Value: "2"},
+	}
+	return p
+}
+
+func TestBackupRestore(t *testing.T) {
+	ctx := context.Background()
+	src := source(t)
+
+	var archive bytes.Buffer
+	manifest, err := Backup(ctx, src.serve(), &archive)
+	if err != nil {
+		t.Fatalf("Backup: %v", err)
+	}
+
+	// Entries come in the order Restore needs them
+	var names []string
+	documents := map[string]string{}
+	tr := tar.NewReader(bytes.NewReader(archive.Bytes()))
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		names = append(names, header.Name)
+		if strings.HasPrefix(header.Name, documentsPath+"/") {
+			data, _ := io.ReadAll(tr)
+			documents[header.Name] = string(data)
+		}
+	}
+	wantNames := []string{
+		"manifest.json",
+		"schema.json",
+		"documents/main/posts.jsonl",
+		"documents/main/users.jsonl",
+		"files/media/large",
+		"files/media/small",
+	}
+	if !reflect.DeepEqual(names, wantNames) {
+		t.Errorf("entries = %q, want %q", names, wantNames)
+	}
+	if !strings.Contains(documents["documents/main/posts.jsonl"], `"author":"u1"`) {
+		t.Errorf("related documents weren't exported as ids:\n%s", documents["documents/main/posts.jsonl"])
+	}
+
+	wantCollections := []CollectionEntry{
+		{DatabaseId: "main", CollectionId: "posts", Documents: 3, Path: "documents/main/posts.jsonl"},
+		{DatabaseId: "main", CollectionId: "users", Documents: 2, Path: "documents/main/users.jsonl"},
+	}
+	if !reflect.DeepEqual(manifest.Collections, wantCollections) {
+		t.Errorf("manifest collections = %+v, want %+v", manifest.Collections, wantCollections)
+	}
+	if len(manifest.Buckets) != 1 || len(manifest.Buckets[0].Files) != 2 || manifest.Buckets[0].Files[0].Path != "files/media/large" {
+		t.Errorf("manifest buckets = %+v", manifest.Buckets)
+	}
+	if len(manifest.Functions) != 1 || len(manifest.Functions[0].Variables) != 2 {
+		t.Errorf("manifest functions = %+v", manifest.Functions)
+	}
+
+	// The destination already holds the bucket, the small file and one of
+	// the variables
+	dst := newFakeProject(t)
+	dst.buckets = []*appwrite.Bucket{{Id: "media", Name: "Old media", Enabled: true}}
+	dst.files["media"] = []*appwrite.File{{Id: "small", BucketId: "media", Name: "small.txt", SizeOriginal: 3, ChunksTotal: 1, ChunksUploaded: 1}}
+	dst.content["media/small"] = []byte("old")
+	dst.variables["fn"] = []*appwrite.Variable{{Id: "old", FunctionId: "fn", Key: "A", Value: "0"}}
+
+	report, err := Restore(ctx, dst.serve(), bytes.NewReader(archive.Bytes()), RestoreOptions{Concurrency: 2})
+	if err != nil {
+		t.Fatalf("Restore: %v", err)
+	}
+
+	posts := report.Documents["main/posts"]
+	if posts == nil || posts.Imported != 2 || len(posts.Failed) != 1 || posts.Failed[0].DocumentId != "p3" {
+		t.Errorf("posts import = %+v, want p3 failing on its missing author", posts)
+	}
+	if users := report.Documents["main/users"]; users == nil || users.Imported != 2 || len(users.Failed) != 0 {
+		t.Errorf("users import = %+v, want 2 documents imported", users)
+	}
+	if report.Buckets != 1 || report.Files != 1 || report.Variables != 2 {
+		t.Errorf("report = %+v, want 1 bucket, 1 file and 2 variables", report)
+	}
+
+	// Relationships were set once the related documents existed
+	if p1 := dst.document("main/posts", "p1"); p1 == nil || p1["author"] != "u1" || p1["views"] != json.Number(bigInt) {
+		t.Errorf("p1 = %v, want the author u1 and %s views", p1, bigInt)
+	}
+	if p2 := dst.document("main/posts", "p2"); p2 == nil || p2["author"] != nil {
+		t.Errorf("p2 = %v, want no author", p2)
+	}
+	if p3 := dst.document("main/posts", "p3"); p3 == nil || p3["author"] != nil {
+		t.Errorf("p3 = %v, want no author", p3)
+	}
+	if views := dst.collection("main", "posts").Attributes[1]; views.Key != "views" || views.Default != json.Number(bigInt) {
+		t.Errorf("views attribute = %+v, want the default %s", views, bigInt)
+	}
+
+	if bucket := dst.bucket("media"); bucket.Name != "Media" || bucket.MaximumFileSize != 2*appwrite.ChunkSize {
+		t.Errorf("bucket = %+v, want it updated", bucket)
+	}
+	if !bytes.Equal(dst.content["media/large"], src.content["media/large"]) {
+		t.Errorf("large file holds %d bytes, want %d", len(dst.content["media/large"]), len(src.content["media/large"]))
+	}
+	if string(dst.content["media/small"]) != "old" {
+		t.Errorf("small file = %q, want the existing file kept", dst.content["media/small"])
+	}
+	size := len(src.content["media/large"])
+	wantWrites := []string{
+		"POST /storage/buckets",
+		"PUT /storage/buckets/media",
+		fmt.Sprintf("POST /storage/buckets/media/files bytes 0-%d/%d", appwrite.ChunkSize-1, size),
+		fmt.Sprintf("POST /storage/buckets/media/files bytes %d-%d/%d", appwrite.ChunkSize, size-1, size),
+		"POST /storage/buckets/media/files",
+		"PUT /functions/fn/variables/old",
+		"POST /functions/fn/variables",
+	}
+	if !reflect.DeepEqual(dst.writes, wantWrites) {
+		t.Errorf("writes:\n%s\nwant:\n%s", strings.Join(dst.writes, "\n"), strings.Join(wantWrites, "\n"))
+	}
+	var variables []string
+	for _, variable := range dst.variables["fn"] {
+		variables = append(variables, variable.Key+"="+variable.Value)
+	}
+	if want := []string{"A=1", "B=2"}; !reflect.DeepEqual(variables, want) {
+		t.Errorf("variables = %v, want %v", variables, want)
+	}
+}
+
+func TestSizedReader(t *testing.T) {
+	r := &sizedReader{r: strings.NewReader("0123456789"), n: 10}
+	buf := make([]byte, 4)
+	for _, want := range []int{6, 2, 0} {
+		if _, err := r.Read(buf); err != nil {
+			t.Fatal(err)
+		}
+		if r.Len() != want {
+			t.Errorf("Len = %d, want %d", r.Len(), want)
+		}
+	}
+}
@@ -0,0 +1,401 @@
+package backup
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	appwrite "github.com/appwrite/sdk-for-go"
+	"github.com/appwrite/sdk-for-go/schema"
+)
+
+// RestoreOptions tunes a restore
+type RestoreOptions struct {
+	// Concurrency is the number of documents created at once,
+	// appwrite.DefaultImportConcurrency when zero
+	Concurrency int
+}
+
+// Report tells what a restore did
+type Report struct {
+	// Documents holds the import results keyed by "databaseId/collectionId".
+	// Relationships that couldn't be restored are reported as failures of
+	// the row of their document.
+	Documents map[string]*appwrite.ImportResult
+	Buckets   int
+	Files     int
+	Variables int
+}
+
+// Restore recreates the content of an archive written by Backup in the
+// project, in dependency order: databases and collections first, then
+// documents, the relationships between them, buckets, files and at last
+// function variables. Relationships are set once every document exists, so
+// that documents can point to documents of any collection.
+//
+// Existing buckets and variables are updated and existing files are kept.
+// Functions aren't part of the archive and must already exist for their
+// variables to be restored.
+func Restore(ctx context.Context, project Project, r io.Reader, opts RestoreOptions) (*Report, error) {
+	rs := &restorer{
+		ctx:     ctx,
+		project: project,
+		opts:    opts,
+		report:  &Report{Documents: map[string]*appwrite.ImportResult{}},
+	}
+
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return rs.report, fmt.Errorf("backup: reading archive: %w", err)
+		}
+		if err := rs.entry(header, tr); err != nil {
+			return rs.report, fmt.Errorf("backup: %s: %w", header.Name, err)
+		}
+	}
+	if rs.manifest == nil {
+		return rs.report, fmt.Errorf("backup: archive without %s", manifestPath)
+	}
+
+	if err := rs.restoreRelationships(); err != nil {
+		return rs.report, err
+	}
+	if err := rs.restoreBuckets(); err != nil {
+		return rs.report, err
+	}
+	if err := rs.restoreVariables(); err != nil {
+		return rs.report, err
+	}
+	return rs.report, nil
+}
+
+// relationship holds the relationship attributes of a document, restored
+// once every document exists
+type relationship struct {
+	databaseId   string
+	collectionId string
+	documentId   string
+	row          int
+	data         map[string]interface{}
+}
+
+type restorer struct {
+	ctx     context.Context
+	project Project
+	opts    RestoreOptions
+	report  *Report
+
+	manifest       *Manifest
+	collections    map[string]CollectionEntry
+	files          map[string]FileEntry
+	relationships  []relationship
+	bucketsCreated bool
+}
+
+func (rs *restorer) entry(header *tar.Header, r io.Reader) error {
+	if header.Typeflag != tar.TypeReg {
+		return nil
+	}
+	if header.Name == manifestPath {
+		return rs.readManifest(r)
+	}
+	if rs.manifest == nil {
+		return fmt.Errorf("archive doesn't start with %s", manifestPath)
+	}
+
+	switch {
+	case header.Name == schemaPath:
+		if rs.project.Database == nil {
+			return nil
+		}
+		return rs.restoreSchema(r)
+	case strings.HasPrefix(header.Name, documentsPath+"/"):
+		if rs.project.Database == nil {
+			return nil
+		}
+		entry, ok := rs.collections[header.Name]
+		if !ok {
+			return fmt.Errorf("not in the manifest")
+		}
+		return rs.restoreDocuments(entry, r)
+	case strings.HasPrefix(header.Name, filesPath+"/"):
+		if rs.project.Storage == nil {
+			return nil
+		}
+		entry, ok := rs.files[header.Name]
+		if !ok {
+			return fmt.Errorf("not in the manifest")
+		}
+		// Documents come before files, so every relationship can be set
+		if err := rs.restoreRelationships(); err != nil {
+			return err
+		}
+		if err := rs.restoreBuckets(); err != nil {
+			return err
+		}
+		return rs.restoreFile(entry, header.Size, r)
+	}
+	// Entries of later versions of the format
+	return nil
+}
+
+func (rs *restorer) readManifest(r io.Reader) error {
+	var manifest Manifest
+	if err := json.NewDecoder(r).Decode(&manifest); err != nil {
+		return err
+	}
+	if manifest.Version > Version {
+		return fmt.Errorf("archive version %d is newer than the supported version %d", manifest.Version, Version)
+	}
+
+	rs.manifest = &manifest
+	rs.collections = map[string]CollectionEntry{}
+	for _, entry := range manifest.Collections {
+		rs.collections[entry.Path] = entry
+	}
+	rs.files = map[string]FileEntry{}
+	for _, bucket := range manifest.Buckets {
+		for _, entry := range bucket.Files {
+			rs.files[entry.Path] = entry
+		}
+	}
+	return nil
+}
+
+// restoreSchema creates the databases, collections, attributes and indexes
+// missing from the project
+func (rs *restorer) restoreSchema(r io.Reader) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	spec, err := schema.Parse(data, schema.UnmarshalJSON)
+	if err != nil {
+		return err
+	}
+	plan, err := schema.Diff(rs.ctx, rs.project.Database, spec, schema.Options{})
+	if err != nil {
+		return err
+	}
+	return schema.Apply(rs.ctx, rs.project.Database, plan)
+}
+
+// restoreDocuments imports the documents of a collection without their
+// relationship attributes, which are kept for restoreRelationships
+func (rs *restorer) restoreDocuments(entry CollectionEntry, r io.Reader) error {
+	db := rs.project.Database
+	collection, err := db.GetCollectionContext(rs.ctx, entry.DatabaseId, entry.CollectionId)
+	if err != nil {
+		return err
+	}
+	// Relationships are left out of the import, and only the parent side of
+	// two-way ones is restored, the child side following it
+	keys := map[string]bool{}
+	for _, attribute := range collection.Attributes {
+		if attribute.Type == appwrite.AttributeTypeRelationship {
+			keys[attribute.Key] = attribute.Side != "child"
+		}
+	}
+
+	var pending []relationship
+	pr, pw := io.Pipe()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		pw.CloseWithError(stripRelationships(r, pw, keys, func(row int, documentId string, data map[string]interface{}) {
+			pending = append(pending, relationship{
+				databaseId:   entry.DatabaseId,
+				collectionId: entry.CollectionId,
+				documentId:   documentId,
+				row:          row,
+				data:         data,
+			})
+		}))
+	}()
+
+	result, err := db.ImportDocuments(rs.ctx, entry.DatabaseId, entry.CollectionId, pr, appwrite.FormatJSONL, rs.opts.Concurrency)
+	pr.CloseWithError(io.ErrClosedPipe)
+	<-done
+	if result != nil {
+		rs.report.Documents[entry.DatabaseId+"/"+entry.CollectionId] = result
+	}
+	if err != nil {
+		return err
+	}
+
+	// Leave out the relationships of documents that weren't created
+	failed := map[int]bool{}
+	for _, failure := range result.Failed {
+		failed[failure.Row] = true
+	}
+	for _, rel := range pending {
+		if !failed[rel.row] {
+			rs.relationships = append(rs.relationships, rel)
+		}
+	}
+	return nil
+}
+
+// stripRelationships copies JSONL rows from r to w without the given keys,
+// passing the values of the keys mapped to true to found. Rows it can't
+// decode are copied as they are, to be reported by the import.
+func stripRelationships(r io.Reader, w io.Writer, keys map[string]bool, found func(row int, documentId string, data map[string]interface{})) error {
+	br := bufio.NewReader(r)
+	bw := bufio.NewWriter(w)
+	for row := 1; ; row++ {
+		line, err := br.ReadBytes('\n')
+		if len(line) == 0 && err != nil {
+			if err == io.EOF {
+				return bw.Flush()
+			}
+			return err
+		}
+
+		dec := json.NewDecoder(bytes.NewReader(line))
+		dec.UseNumber()
+		var fields map[string]interface{}
+		if len(keys) > 0 && dec.Decode(&fields) == nil && fields != nil {
+			data := map[string]interface{}{}
+			for key, keep := range keys {
+				if val, ok := fields[key]; ok {
+					if keep && val != nil {
+						data[key] = val
+					}
+					delete(fields, key)
+				}
+			}
+			if documentId, _ := fields["$id"].(string); documentId != "" && len(data) > 0 {
+				found(row, documentId, data)
+			}
+			if line, err = json.Marshal(fields); err != nil {
+				return err
+			}
+			line = append(line, '\n')
+		}
+		if _, err := bw.Write(line); err != nil {
+			return err
+		}
+	}
+}
+
+// restoreRelationships sets the relationships of the documents imported so
+// far
+func (rs *restorer) restoreRelationships() error {
+	for _, rel := range rs.relationships {
+		_, err := rs.project.Database.UpdateDocumentContext(rs.ctx, rel.databaseId, rel.collectionId, rel.documentId, rel.data, nil)
+		if err == nil {
+			continue
+		}
+		if rs.ctx.Err() != nil {
+			return err
+		}
+		result := rs.report.Documents[rel.databaseId+"/"+rel.collectionId]
+		result.Imported--
+		result.Failed = append(result.Failed, appwrite.ImportRowError{
+			Row:        rel.row,
+			DocumentId: rel.documentId,
+			Err:        fmt.Errorf("restoring relationships: %w", err),
+		})
+	}
+	rs.relationships = nil
+	return nil
+}
+
+// restoreBuckets creates the buckets of the manifest, updating the existing
+// ones
+func (rs *restorer) restoreBuckets() error {
+	if rs.bucketsCreated || rs.project.Storage == nil {
+		return nil
+	}
+	rs.bucketsCreated = true
+
+	storage := rs.project.Storage
+	for _, entry := range rs.manifest.Buckets {
+		b := entry.Bucket
+		_, err := storage.CreateBucketContext(rs.ctx, b.Id, b.Name, b.Permissions, b.FileSecurity, b.Enabled, b.MaximumFileSize, b.AllowedFileExtensions, b.CompressionType, b.Encryption, b.Antivirus)
+		if errors.Is(err, appwrite.ErrConflict) {
+			_, err = storage.UpdateBucketContext(rs.ctx, b.Id, b.Name, b.Permissions, b.FileSecurity, b.Enabled, b.MaximumFileSize, b.AllowedFileExtensions, b.CompressionType, b.Encryption, b.Antivirus)
+		}
+		if err != nil {
+			return fmt.Errorf("backup: restoring bucket %s: %w", b.Id, err)
+		}
+		rs.report.Buckets++
+	}
+	return nil
+}
+
+// restoreFile uploads a file, keeping the file when it already exists
+func (rs *restorer) restoreFile(entry FileEntry, size int64, r io.Reader) error {
+	file := entry.File
+	_, err := rs.project.Storage.CreateFileContext(rs.ctx, file.BucketId, file.Id, &sizedReader{r: r, n: size}, file.Name, file.Permissions)
+	switch {
+	case errors.Is(err, appwrite.ErrConflict):
+		return nil
+	case err != nil:
+		return err
+	}
+	rs.report.Files++
+	return nil
+}
+
+// restoreVariables creates the variables of the manifest, updating the ones
+// with the same key
+func (rs *restorer) restoreVariables() error {
+	functions := rs.project.Functions
+	if functions == nil {
+		return nil
+	}
+	for _, entry := range rs.manifest.Functions {
+		if len(entry.Variables) == 0 {
+			continue
+		}
+		ids := map[string]string{}
+		for variable, err := range functions.AllVariables(rs.ctx, entry.Id, nil, 100) {
+			if err != nil {
+				return fmt.Errorf("backup: restoring variables of function %s: %w", entry.Id, err)
+			}
+			ids[variable.Key] = variable.Id
+		}
+
+		var err error
+		for _, variable := range entry.Variables {
+			if variableId, ok := ids[variable.Key]; ok {
+				_, err = functions.UpdateVariableContext(rs.ctx, entry.Id, variableId, variable.Key, variable.Value)
+			} else {
+				_, err = functions.CreateVariableContext(rs.ctx, entry.Id, variable.Key, variable.Value)
+			}
+			if err != nil {
+				return fmt.Errorf("backup: restoring variable %s of function %s: %w", variable.Key, entry.Id, err)
+			}
+			rs.report.Variables++
+		}
+	}
+	return nil
+}
+
+// sizedReader tells CreateFile the size of a tar entry so that it is
+// uploaded in chunks instead of being read into memory
+type sizedReader struct {
+	r io.Reader
+	n int64
+}
+
+func (s *sizedReader) Read(p []byte) (int, error) {
+	n, err := s.r.Read(p)
+	s.n -= int64(n)
+	return n, err
+}
+
+func (s *sizedReader) Len() int {
+	return int(s.n)
+}
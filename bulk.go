@@ -0,0 +1,538 @@
+package appwrite
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/appwrite/sdk-for-go/id"
+)
+
+// DocumentFormat is a format documents are exported to and imported from
+type DocumentFormat string
+
+const (
+	// FormatJSONL writes a JSON object per line, as returned by the server
+	FormatJSONL DocumentFormat = "jsonl"
+	// FormatCSV writes a header row with the system fields and the attribute
+	// keys, then a row per document. Arrays, objects and permissions are
+	// written as JSON.
+	FormatCSV DocumentFormat = "csv"
+)
+
+// System fields written to and read from CSV files
+var csvSystemFields = []string{"$id", "$createdAt", "$updatedAt", "$permissions"}
+
+// DefaultImportConcurrency is the number of documents ImportDocuments creates
+// at once when no concurrency is given
+const DefaultImportConcurrency = 4
+
+// ImportRowError is the failure of a single row of an import
+type ImportRowError struct {
+	// Row is the 1-based position of the document in the input, the CSV
+	// header and blank JSONL lines included
+	Row        int
+	DocumentId string
+	Err        error
+}
+
+func (e ImportRowError) Error() string {
+	if e.DocumentId != "" {
+		return fmt.Sprintf("row %d (%s): %v", e.Row, e.DocumentId, e.Err)
+	}
+	return fmt.Sprintf("row %d: %v", e.Row, e.Err)
+}
+
+func (e ImportRowError) Unwrap() error {
+	return e.Err
+}
+
+// ImportResult reports how an import went
+type ImportResult struct {
+	Imported int
+	// Failed lists the rows that couldn't be imported, ordered by row
+	Failed []ImportRowError
+}
+
+// ExportDocuments write every document of a collection to w in the given
+// format, paging through them with cursors. Related documents are written as
+// their ids so that the output can be imported back. It returns the number of
+// documents fully written to w, which on failure are the ones preceding the
+// first partially written or missing document.
+func (srv *Database) ExportDocuments(ctx context.Context, databaseId, collectionId string, w io.Writer, format DocumentFormat) (int, error) {
+	out := &countingWriter{w: w}
+	// csv.NewWriter reuses bw rather than buffering on top of it
+	bw := bufio.NewWriter(out)
+	var write func(doc Document) error
+
+	switch format {
+	case FormatJSONL:
+		enc := json.NewEncoder(bw)
+		enc.SetEscapeHTML(false)
+		write = func(doc Document) error {
+			doc.Fields = relatedIds(doc.Fields)
+			return enc.Encode(doc)
+		}
+	case FormatCSV:
+		collection, err := srv.GetCollectionContext(ctx, databaseId, collectionId)
+		if err != nil {
+			return 0, err
+		}
+		header := append([]string{}, csvSystemFields...)
+		for _, attribute := range collection.Attributes {
+			header = append(header, attribute.Key)
+		}
+
+		cw := csv.NewWriter(bw)
+		if err := cw.Write(header); err != nil {
+			return 0, err
+		}
+		record := make([]string, len(header))
+		write = func(doc Document) error {
+			permissions, err := json.Marshal(doc.Permissions)
+			if err != nil {
+				return err
+			}
+			record[0], record[1], record[2], record[3] = doc.Id, doc.CreatedAt, doc.UpdatedAt, string(permissions)
+			fields := relatedIds(doc.Fields)
+			for i, key := range header[len(csvSystemFields):] {
+				if record[len(csvSystemFields)+i], err = csvValue(fields[key]); err != nil {
+					return fmt.Errorf("appwrite: document %s: attribute %q: %w", doc.Id, key, err)
+				}
+			}
+			return cw.Write(record)
+		}
+	default:
+		return 0, fmt.Errorf("appwrite: unknown document format %q", format)
+	}
+
+	// Documents sit in bw until it fills up, so they are only counted once
+	// the offset at which they end was written to w
+	count := 0
+	var ends []int64
+	written := func() int {
+		for len(ends) > 0 && ends[0] <= out.n {
+			ends = ends[1:]
+			count++
+		}
+		return count
+	}
+	finish := func(err error) (int, error) {
+		if flushErr := bw.Flush(); err == nil {
+			err = flushErr
+		}
+		return written(), err
+	}
+
+	for doc, err := range srv.AllDocuments(ctx, databaseId, collectionId, nil, 100) {
+		if err != nil {
+			return finish(err)
+		}
+		if err := write(doc); err != nil {
+			return finish(err)
+		}
+		ends = append(ends, out.n+int64(bw.Buffered()))
+		written()
+	}
+	return finish(nil)
+}
+
+// countingWriter counts the bytes written to w
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// ImportDocuments create documents in a collection out of rows read from r
+// in the given format, creating up to concurrency documents at once. Values
+// are converted to the types of the attributes they map to, so CSV cells and
+// JSON strings holding numbers or booleans are accepted. The $id field gives
+// the id of the document, a unique one being generated when it is missing,
+// and $permissions its permissions; the other system fields are ignored.
+//
+// Rows that can't be decoded or created are reported in the result and don't
+// stop the import. The returned error is only set when the import couldn't
+// run to the end, such as when reading r fails or ctx is done.
+func (srv *Database) ImportDocuments(ctx context.Context, databaseId, collectionId string, r io.Reader, format DocumentFormat, concurrency int) (*ImportResult, error) {
+	if concurrency <= 0 {
+		concurrency = DefaultImportConcurrency
+	}
+
+	collection, err := srv.GetCollectionContext(ctx, databaseId, collectionId)
+	if err != nil {
+		return nil, err
+	}
+	attributes := make(map[string]Attribute, len(collection.Attributes))
+	for _, attribute := range collection.Attributes {
+		attributes[attribute.Key] = attribute
+	}
+
+	var read func() (int, map[string]interface{}, error)
+	switch format {
+	case FormatJSONL:
+		read = jsonlRows(r)
+	case FormatCSV:
+		if read, err = csvRows(r, attributes); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("appwrite: unknown document format %q", format)
+	}
+
+	result := &ImportResult{}
+	var mu sync.Mutex
+	fail := func(row int, documentId string, err error) {
+		if documentId == id.Unique() {
+			documentId = ""
+		}
+		mu.Lock()
+		result.Failed = append(result.Failed, ImportRowError{Row: row, DocumentId: documentId, Err: err})
+		mu.Unlock()
+	}
+
+	jobs := make(chan importJob)
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				if _, err := srv.CreateDocumentContext(ctx, databaseId, collectionId, j.documentId, j.data, j.permissions); err != nil {
+					fail(j.row, j.documentId, err)
+					continue
+				}
+				mu.Lock()
+				result.Imported++
+				mu.Unlock()
+			}
+		}()
+	}
+
+	var runErr error
+	for runErr == nil {
+		// Stop reading rows once ctx is done rather than handing them to
+		// workers whose requests would fail
+		if runErr = ctx.Err(); runErr != nil {
+			break
+		}
+		row, fields, err := read()
+		if err == io.EOF {
+			break
+		}
+		var rowErr ImportRowError
+		switch {
+		case errors.As(err, &rowErr):
+			fail(rowErr.Row, "", rowErr.Err)
+			continue
+		case err != nil:
+			runErr = err
+			continue
+		}
+
+		j := importJob{row: row, documentId: id.Unique(), data: make(map[string]interface{}, len(fields))}
+		if err := j.decode(fields, attributes); err != nil {
+			fail(row, j.documentId, err)
+			continue
+		}
+		select {
+		case jobs <- j:
+		case <-ctx.Done():
+			runErr = ctx.Err()
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	sort.Slice(result.Failed, func(i, k int) bool {
+		return result.Failed[i].Row < result.Failed[k].Row
+	})
+	return result, runErr
+}
+
+// importJob is a document to create out of a row
+type importJob struct {
+	row         int
+	documentId  string
+	data        map[string]interface{}
+	permissions []string
+}
+
+// decode splits the fields of a row into the id, permissions and data of the
+// document to create. The id is read first so that errors about the other
+// fields can name the document.
+func (j *importJob) decode(fields map[string]interface{}, attributes map[string]Attribute) error {
+	if val, ok := fields["$id"]; ok {
+		documentId, ok := val.(string)
+		if !ok {
+			return fmt.Errorf("$id must be a string, got %T", val)
+		}
+		if documentId != "" {
+			j.documentId = documentId
+		}
+	}
+	if val, ok := fields["$permissions"]; ok {
+		permissions, err := permissionsValue(val)
+		if err != nil {
+			return fmt.Errorf("$permissions: %w", err)
+		}
+		j.permissions = permissions
+	}
+
+	for key, val := range fields {
+		if strings.HasPrefix(key, "$") {
+			continue
+		}
+		attribute, ok := attributes[key]
+		if !ok {
+			return fmt.Errorf("unknown attribute %q", key)
+		}
+		if val == nil {
+			continue
+		}
+		v, err := coerce(attribute, val)
+		if err != nil {
+			return fmt.Errorf("attribute %q: %w", key, err)
+		}
+		j.data[key] = v
+	}
+	return nil
+}
+
+// jsonlRows returns a function reading the next JSONL row, skipping blank
+// lines, until io.EOF
+func jsonlRows(r io.Reader) func() (int, map[string]interface{}, error) {
+	br := bufio.NewReader(r)
+	row := 0
+	return func() (int, map[string]interface{}, error) {
+		for {
+			line, err := br.ReadBytes('\n')
+			if len(line) == 0 && err != nil {
+				return 0, nil, err
+			}
+			row++
+			line = bytes.TrimSpace(line)
+			if len(line) == 0 {
+				continue
+			}
+
+			dec := json.NewDecoder(bytes.NewReader(line))
+			dec.UseNumber()
+			var fields map[string]interface{}
+			if err := dec.Decode(&fields); err != nil {
+				return row, nil, ImportRowError{Row: row, Err: err}
+			}
+			if fields == nil {
+				return row, nil, ImportRowError{Row: row, Err: fmt.Errorf("not a JSON object")}
+			}
+			return row, fields, nil
+		}
+	}
+}
+
+// csvRows reads the CSV header, checking that every column maps to a system
+// field or an attribute, and returns a function reading the next row until
+// io.EOF. Empty cells are left out of the row.
+func csvRows(r io.Reader, attributes map[string]Attribute) (func() (int, map[string]interface{}, error), error) {
+	cr := csv.NewReader(r)
+	header, err := cr.Read()
+	if err == io.EOF {
+		return func() (int, map[string]interface{}, error) { return 0, nil, io.EOF }, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("appwrite: reading CSV header: %w", err)
+	}
+	for _, column := range header {
+		if _, ok := attributes[column]; !ok && !strings.HasPrefix(column, "$") {
+			return nil, fmt.Errorf("appwrite: CSV column %q matches no attribute", column)
+		}
+	}
+
+	row := 1
+	return func() (int, map[string]interface{}, error) {
+		record, err := cr.Read()
+		if err == io.EOF {
+			return 0, nil, err
+		}
+		row++
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			return row, nil, ImportRowError{Row: row, Err: err}
+		}
+		if err != nil {
+			return row, nil, err
+		}
+
+		fields := make(map[string]interface{}, len(header))
+		for i, column := range header {
+			if record[i] != "" {
+				fields[column] = record[i]
+			}
+		}
+		return row, fields, nil
+	}, nil
+}
+
+// coerce converts a decoded JSON value or a CSV cell to the type of an
+// attribute
+func coerce(attribute Attribute, val interface{}) (interface{}, error) {
+	if attribute.Type == AttributeTypeRelationship {
+		// Related documents are given by id, as a list of ids or as JSON
+		if s, ok := val.(string); ok {
+			if trimmed := strings.TrimSpace(s); strings.HasPrefix(trimmed, "[") || strings.HasPrefix(trimmed, "{") {
+				return decodeCell(trimmed)
+			}
+		}
+		return val, nil
+	}
+
+	if !attribute.Array {
+		return coerceScalar(attribute.Type, val)
+	}
+	if s, ok := val.(string); ok {
+		decoded, err := decodeCell(s)
+		if err != nil {
+			return nil, err
+		}
+		val = decoded
+	}
+	items, ok := val.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("expected an array, got %T", val)
+	}
+	out := make([]interface{}, len(items))
+	for i, item := range items {
+		v, err := coerceScalar(attribute.Type, item)
+		if err != nil {
+			return nil, fmt.Errorf("item %d: %w", i, err)
+		}
+		out[i] = v
+	}
+	return out, nil
+}
+
+func coerceScalar(kind string, val interface{}) (interface{}, error) {
+	s, isString := val.(string)
+	switch kind {
+	case AttributeTypeInteger:
+		switch v := val.(type) {
+		case json.Number:
+			return v.Int64()
+		case string:
+			return strconv.ParseInt(strings.TrimSpace(v), 10, 64)
+		}
+	case AttributeTypeFloat:
+		switch v := val.(type) {
+		case json.Number:
+			return v.Float64()
+		case string:
+			return strconv.ParseFloat(strings.TrimSpace(v), 64)
+		}
+	case AttributeTypeBoolean:
+		switch v := val.(type) {
+		case bool:
+			return v, nil
+		case string:
+			return strconv.ParseBool(strings.TrimSpace(v))
+		}
+	default:
+		if isString {
+			return s, nil
+		}
+	}
+	return nil, fmt.Errorf("cannot use %T as %s", val, kind)
+}
+
+// decodeCell decodes a cell holding JSON
+func decodeCell(s string) (interface{}, error) {
+	dec := json.NewDecoder(strings.NewReader(s))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// permissionsValue reads permissions given as a JSON array or a CSV cell
+// holding one
+func permissionsValue(val interface{}) ([]string, error) {
+	if s, ok := val.(string); ok {
+		decoded, err := decodeCell(s)
+		if err != nil {
+			return nil, err
+		}
+		val = decoded
+	}
+	if val == nil {
+		return nil, nil
+	}
+	items, ok := val.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("expected an array, got %T", val)
+	}
+	permissions := make([]string, len(items))
+	for i, item := range items {
+		if permissions[i], ok = item.(string); !ok {
+			return nil, fmt.Errorf("expected strings, got %T", item)
+		}
+	}
+	return permissions, nil
+}
+
+// relatedIds replaces the related documents in fields by their ids
+func relatedIds(fields map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(fields))
+	for key, val := range fields {
+		switch v := val.(type) {
+		case Document:
+			out[key] = v.Id
+		case []Document:
+			ids := make([]string, len(v))
+			for i, doc := range v {
+				ids[i] = doc.Id
+			}
+			out[key] = ids
+		default:
+			out[key] = val
+		}
+	}
+	return out
+}
+
+// csvValue formats an attribute value as a CSV cell
+func csvValue(val interface{}) (string, error) {
+	switch v := val.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case json.Number:
+		return v.String(), nil
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(val); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
@@ -0,0 +1,355 @@
+package appwrite
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// importServer serves a collection and records the documents created in it,
+// rejecting the one with the id "taken" as already existing
+type importServer struct {
+	mu        sync.Mutex
+	created   map[string]interface{}
+	requests  int
+	onRequest func(n int)
+}
+
+const importCollection = `{"$id":"posts","attributes":[
+	{"key":"title","type":"string"},
+	{"key":"views","type":"integer"},
+	{"key":"rating","type":"double"},
+	{"key":"published","type":"boolean"},
+	{"key":"tags","type":"string","array":true},
+	{"key":"author","type":"relationship"}
+]}`
+
+func (s *importServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodGet {
+		fmt.Fprint(w, importCollection)
+		return
+	}
+
+	var params map[string]interface{}
+	dec := json.NewDecoder(r.Body)
+	dec.UseNumber()
+	if err := dec.Decode(&params); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	documentId, _ := params["documentId"].(string)
+	delete(params, "documentId")
+
+	s.mu.Lock()
+	s.requests++
+	n := s.requests
+	taken := documentId == "taken"
+	if !taken {
+		s.created[documentId] = params
+	}
+	s.mu.Unlock()
+
+	if s.onRequest != nil {
+		s.onRequest(n)
+	}
+	if taken {
+		w.WriteHeader(http.StatusConflict)
+		fmt.Fprint(w, `{"message":"Document with the requested ID already exists.","code":409,"type":"document_already_exists"}`)
+		return
+	}
+	fmt.Fprintf(w, `{"$id":%q}`, documentId)
+}
+
+func newImportServer(t *testing.T, s *importServer) *Database {
+	t.Helper()
+	s.created = map[string]interface{}{}
+	srv := httptest.NewServer(s)
+	t.Cleanup(srv.Close)
+
+	client := NewClient()
+	client.SetEndpoint(srv.URL)
+	db := NewDatabase(client)
+	return &db
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// decoded decodes JSON the way importServer does, for comparisons
+func decoded(t *testing.T, s string) interface{} {
+	t.Helper()
+	dec := json.NewDecoder(strings.NewReader(s))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
+
+func TestImportDocumentsCoercesValues(t *testing.T) {
+	tests := []struct {
+		name   string
+		format DocumentFormat
+		input  string
+	}{
+		{"csv", FormatCSV, `$id,$permissions,$createdAt,title,views,rating,published,tags,author
+d1,"[""read(\""any\"")""]",2024-01-01T00:00:00.000+00:00,Hello,` + bigInt + `,4.5,true,"[""a"",""b""]",u1
+`},
+		{"jsonl", FormatJSONL, `{"$id":"d1","$permissions":["read(\"any\")"],"$createdAt":"2024-01-01T00:00:00.000+00:00","title":"Hello","views":"` + bigInt + `","rating":4.5,"published":"true","tags":["a","b"],"author":"u1"}
+`},
+	}
+
+	want := decoded(t, `{
+		"d1": {
+			"data": {"title":"Hello","views":`+bigInt+`,"rating":4.5,"published":true,"tags":["a","b"],"author":"u1"},
+			"permissions": ["read(\"any\")"]
+		}
+	}`)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &importServer{}
+			db := newImportServer(t, s)
+
+			result, err := db.ImportDocuments(context.Background(), "main", "posts", strings.NewReader(tt.input), tt.format, 2)
+			if err != nil {
+				t.Fatalf("ImportDocuments: %v", err)
+			}
+			if result.Imported != 1 || len(result.Failed) != 0 {
+				t.Errorf("result = %+v, want 1 document imported", result)
+			}
+			if !reflect.DeepEqual(s.created, want) {
+				got, _ := json.Marshal(s.created)
+				t.Errorf("sent %s", got)
+			}
+		})
+	}
+}
+
+func TestImportDocumentsReportsRows(t *testing.T) {
+	tests := []struct {
+		name   string
+		format DocumentFormat
+		input  string
+		sent   []string
+		failed []string
+	}{
+		{"csv", FormatCSV, `$id,title,views,published
+d1,One,1,true
+d2,Two,two,false
+taken,Three,3,true
+,Four,4,maybe
+d5,"Five,5,true
+`, []string{"d1"}, []string{
+			`row 3 (d2): attribute "views": strconv.ParseInt: parsing "two": invalid syntax`,
+			`row 4 (taken): appwrite: Document with the requested ID already exists. (409 document_already_exists)`,
+			`row 5: attribute "published": strconv.ParseBool: parsing "maybe": invalid syntax`,
+			`row 6: parse error on line 6, column 17: extraneous or missing " in quoted-field`,
+		}},
+		{"jsonl", FormatJSONL, `{"$id":"d1","title":"One"}
+
+{"$id":"d3","title":"Three","color":"red"}
+{"$id":"d4",
+{"$id":"d5","views":1.5}
+["d6"]
+{"$id":"d7","tags":"a"}
+{"$id":"d8","title":"Eight"}
+`, []string{"d1", "d8"}, []string{
+			`row 3 (d3): unknown attribute "color"`,
+			`row 4: unexpected EOF`,
+			`row 5 (d5): attribute "views": strconv.ParseInt: parsing "1.5": invalid syntax`,
+			`row 6: json: cannot unmarshal array into Go value of type map[string]interface {}`,
+			`row 7 (d7): attribute "tags": invalid character 'a' looking for beginning of value`,
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &importServer{}
+			db := newImportServer(t, s)
+
+			result, err := db.ImportDocuments(context.Background(), "main", "posts", strings.NewReader(tt.input), tt.format, 3)
+			if err != nil {
+				t.Fatalf("ImportDocuments: %v", err)
+			}
+
+			var failed []string
+			for _, rowErr := range result.Failed {
+				failed = append(failed, rowErr.Error())
+				if strings.Contains(rowErr.Error(), "already exists") && !errors.Is(rowErr, ErrConflict) {
+					t.Errorf("row %d doesn't wrap ErrConflict", rowErr.Row)
+				}
+			}
+			if !reflect.DeepEqual(failed, tt.failed) {
+				t.Errorf("failed rows:\n%s\nwant:\n%s", strings.Join(failed, "\n"), strings.Join(tt.failed, "\n"))
+			}
+			if sent := sortedKeys(s.created); !reflect.DeepEqual(sent, tt.sent) || result.Imported != len(tt.sent) {
+				t.Errorf("created %v and imported %d documents, want %v", sent, result.Imported, tt.sent)
+			}
+		})
+	}
+}
+
+func TestImportDocumentsCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s := &importServer{onRequest: func(n int) {
+		if n == 3 {
+			cancel()
+		}
+	}}
+	db := newImportServer(t, s)
+
+	var input strings.Builder
+	input.WriteString("$id,title\n")
+	const rows = 1000
+	for i := 0; i < rows; i++ {
+		fmt.Fprintf(&input, "d%d,Title %d\n", i, i)
+	}
+
+	result, err := db.ImportDocuments(ctx, "main", "posts", strings.NewReader(input.String()), FormatCSV, 4)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("ImportDocuments error = %v, want context.Canceled", err)
+	}
+
+	s.mu.Lock()
+	requests := s.requests
+	s.mu.Unlock()
+	// Workers stop taking rows once ctx is done, the ones in flight included
+	if requests > 3+4 {
+		t.Errorf("%d documents were sent after cancelling", requests-3)
+	}
+	if result.Imported+len(result.Failed) >= rows {
+		t.Errorf("every row was processed after cancelling: %+v", result)
+	}
+	if result.Imported > requests {
+		t.Errorf("imported %d documents out of %d requests", result.Imported, requests)
+	}
+}
+
+func TestExportDocumentsKeepsLargeIntegers(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/collections/posts"):
+			fmt.Fprint(w, `{"$id":"posts","attributes":[{"key":"views","type":"integer"}]}`)
+		case strings.Contains(r.URL.RawQuery, "cursorAfter"):
+			fmt.Fprint(w, `{"total":1,"documents":[]}`)
+		default:
+			fmt.Fprint(w, `{"total":1,"documents":[{"$id":"d1","views":`+bigInt+`}]}`)
+		}
+	}))
+	defer srv.Close()
+
+	client := NewClient()
+	client.SetEndpoint(srv.URL)
+	db := NewDatabase(client)
+
+	for _, format := range []DocumentFormat{FormatJSONL, FormatCSV} {
+		var buf bytes.Buffer
+		if _, err := db.ExportDocuments(context.Background(), "main", "posts", &buf, format); err != nil {
+			t.Fatalf("ExportDocuments(%s): %v", format, err)
+		}
+		if !strings.Contains(buf.String(), bigInt) {
+			t.Errorf("%s export lost precision:\n%s", format, buf.String())
+		}
+	}
+}
+
+// exportServer serves the posts collection, with a title attribute, and its
+// documents through a documentsServer
+func newExportServer(t *testing.T, s *documentsServer) *Database {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/collections/posts") {
+			fmt.Fprint(w, `{"$id":"posts","attributes":[{"key":"title","type":"string"}]}`)
+			return
+		}
+		s.ServeHTTP(w, r)
+	}))
+	t.Cleanup(srv.Close)
+
+	client := NewClient()
+	client.SetEndpoint(srv.URL)
+	db := NewDatabase(client)
+	return &db
+}
+
+// failingWriter accepts limit bytes, then fails
+type failingWriter struct {
+	bytes.Buffer
+	limit int
+}
+
+var errWriteFailed = errors.New("disk full")
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	if room := w.limit - w.Len(); len(p) > room {
+		w.Buffer.Write(p[:max(room, 0)])
+		return max(room, 0), errWriteFailed
+	}
+	return w.Buffer.Write(p)
+}
+
+// exportedRows returns the rows of an export that were fully written
+func exportedRows(format DocumentFormat, data string) int {
+	rows := strings.Count(data, "\n")
+	if format == FormatCSV {
+		rows-- // the header
+	}
+	return rows
+}
+
+func TestExportDocumentsWriteFailure(t *testing.T) {
+	for _, format := range []DocumentFormat{FormatJSONL, FormatCSV} {
+		for _, limit := range []int{0, 10, 5000, 6000, 9000} {
+			t.Run(fmt.Sprintf("%s/%d", format, limit), func(t *testing.T) {
+				db := newExportServer(t, &documentsServer{count: 1000})
+
+				w := &failingWriter{limit: limit}
+				count, err := db.ExportDocuments(context.Background(), "main", "posts", w, format)
+				if !errors.Is(err, errWriteFailed) {
+					t.Fatalf("error = %v, want %v", err, errWriteFailed)
+				}
+				if rows := exportedRows(format, w.String()); count != max(rows, 0) {
+					t.Errorf("reported %d documents, %d were written:\n%s", count, rows, w.String())
+				}
+			})
+		}
+	}
+}
+
+func TestExportDocumentsFlushesOnError(t *testing.T) {
+	for _, format := range []DocumentFormat{FormatJSONL, FormatCSV} {
+		t.Run(string(format), func(t *testing.T) {
+			db := newExportServer(t, &documentsServer{count: 1000, failAt: 3})
+
+			var buf bytes.Buffer
+			count, err := db.ExportDocuments(context.Background(), "main", "posts", &buf, format)
+			var appwriteErr *AppwriteError
+			if !errors.As(err, &appwriteErr) {
+				t.Fatalf("error = %v, want an *AppwriteError", err)
+			}
+			// The documents of the first two pages were written out
+			if rows := exportedRows(format, buf.String()); count != 200 || rows != 200 {
+				t.Errorf("reported %d documents and wrote %d, want 200", count, rows)
+			}
+		})
+	}
+}
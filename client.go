@@ -1,10 +1,14 @@
 package appwrite
 
 import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
+	"errors"
 	"io/ioutil"
 	"net/http"
-	"net/url"
 	"strings"
 )
 
@@ -14,6 +18,9 @@ type Client struct {
 	endpoint   string
 	headers    map[string]interface{}
 	selfSigned bool
+	rootCAs    *x509.CertPool
+	clientCert *tls.Certificate
+	retry      *RetryPolicy
 }
 
 // SetEndpoint sets the default endpoint to which the Client connects to
@@ -24,6 +31,57 @@ func (clt *Client) SetEndpoint(endpoint string) {
 // SetSelfSigned sets the condition that specify if the Client should allow connections to a server using a self-signed certificate
 func (clt *Client) SetSelfSigned(status bool) {
 	clt.selfSigned = status
+	clt.client = nil
+}
+
+// SetCertificateAuthority adds the PEM encoded CA certificates the Client
+// should trust when verifying the server certificate, in addition to the
+// system pool
+func (clt *Client) SetCertificateAuthority(pemCerts []byte) error {
+	if clt.rootCAs == nil {
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		clt.rootCAs = pool
+	}
+	if !clt.rootCAs.AppendCertsFromPEM(pemCerts) {
+		return errors.New("appwrite: no valid certificates found in PEM data")
+	}
+	clt.client = nil
+	return nil
+}
+
+// SetClientCertificate sets the PEM encoded certificate and private key the
+// Client presents to the server for mutual TLS
+func (clt *Client) SetClientCertificate(certPEM []byte, keyPEM []byte) error {
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return err
+	}
+	clt.clientCert = &cert
+	clt.client = nil
+	return nil
+}
+
+// newHTTPClient creates the HTTP client honouring the Client TLS settings
+func (clt *Client) newHTTPClient() *http.Client {
+	if !clt.selfSigned && clt.rootCAs == nil && clt.clientCert == nil {
+		return &http.Client{}
+	}
+
+	tlsConfig := &tls.Config{
+		RootCAs: clt.rootCAs,
+		// Allow self signed certificates
+		InsecureSkipVerify: clt.selfSigned,
+	}
+	if clt.clientCert != nil {
+		tlsConfig.Certificates = []tls.Certificate{*clt.clientCert}
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	return &http.Client{Transport: transport}
 }
 
 // AddHeader add a new custom header that the Client should send on each request
@@ -66,53 +124,45 @@ func (clt *Client) SetMode(value string) {
 
 // Call an API using Client
 func (clt *Client) Call(method string, path string, headers map[string]interface{}, params map[string]interface{}) (map[string]interface{}, error) {
-	if clt.client == nil {
-		// Create HTTP client
-		clt.client = &http.Client{}
-	}
-
-	if clt.selfSigned {
-		// Allow self signed requests
-	}
-
-	urlPath := clt.endpoint + path
-	isGet := strings.ToUpper(method) == "GET"
-
-	reqBody := new(strings.Reader)
-	if !isGet {
-		frm := url.Values{}
-		for key, val := range params {
-			frm.Add(key, ToString(val))
-		}
-		reqBody = strings.NewReader(frm.Encode())
-	}
+	return clt.CallContext(context.Background(), method, path, headers, params)
+}
 
-	// Create and modify HTTP request before sending
-	req, err := http.NewRequest(method, urlPath, reqBody)
+// CallContext calls an API using Client and decodes the JSON response. The
+// request is cancelled when ctx is done.
+func (clt *Client) CallContext(ctx context.Context, method string, path string, headers map[string]interface{}, params map[string]interface{}) (map[string]interface{}, error) {
+	responseData, err := clt.CallAPIContext(ctx, method, path, headers, params)
 	if err != nil {
 		return nil, err
 	}
 
-	// Set Client headers
-	for key, val := range clt.headers {
-		req.Header.Set(key, ToString(val))
-	}
+	var jsonResponse map[string]interface{}
+	json.Unmarshal(responseData, &jsonResponse)
 
-	// Set Custom headers
-	for key, val := range headers {
-		req.Header.Set(key, ToString(val))
-	}
+	return jsonResponse, nil
+}
+
+// CallAPI calls an API using Client and returns the raw response body
+func (clt *Client) CallAPI(method string, path string, headers map[string]interface{}, params map[string]interface{}) ([]byte, error) {
+	return clt.CallAPIContext(context.Background(), method, path, headers, params)
+}
 
-	if isGet {
-		q := req.URL.Query()
-		for key, val := range params {
-			q.Add(key, ToString(val))
+// CallAPIContext calls an API using Client and returns the raw response body.
+// The request is cancelled when ctx is done.
+func (clt *Client) CallAPIContext(ctx context.Context, method string, path string, headers map[string]interface{}, params map[string]interface{}) ([]byte, error) {
+	var query map[string]interface{}
+	var body []byte
+	contentType := ""
+	if strings.ToUpper(method) == "GET" {
+		query = params
+	} else {
+		var err error
+		if body, err = json.Marshal(params); err != nil {
+			return nil, err
 		}
-		req.URL.RawQuery = q.Encode()
+		contentType = "application/json"
 	}
 
-	// Make request
-	response, err := clt.client.Do(req)
+	response, err := clt.send(ctx, method, path, headers, query, body, contentType)
 	if err != nil {
 		return nil, err
 	}
@@ -120,76 +170,64 @@ func (clt *Client) Call(method string, path string, headers map[string]interface
 	// Handle response
 	defer response.Body.Close()
 
-	responseData, err := ioutil.ReadAll(response.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	var jsonResponse map[string]interface{}
-	json.Unmarshal(responseData, &jsonResponse)
-
-	return jsonResponse, nil
+	return ioutil.ReadAll(response.Body)
 }
 
-func (clt *Client) CallAPI(method string, path string, headers map[string]interface{}, params map[string]interface{}) ([]byte, error) {
+// send makes a request with the given query params and raw body. Non-2xx
+// responses are returned as an *AppwriteError, otherwise the caller must
+// close the body of the returned response.
+func (clt *Client) send(ctx context.Context, method string, path string, headers map[string]interface{}, query map[string]interface{}, body []byte, contentType string) (*http.Response, error) {
 	if clt.client == nil {
 		// Create HTTP client
-		clt.client = &http.Client{}
-	}
-
-	if clt.selfSigned {
-		// Allow self signed requests
+		clt.client = clt.newHTTPClient()
 	}
 
 	urlPath := clt.endpoint + path
-	isGet := strings.ToUpper(method) == "GET"
 
-	reqBody := new(strings.Reader)
-	if !isGet {
-		frm := url.Values{}
-		for key, val := range params {
-			frm.Add(key, ToString(val))
+	newRequest := func() (*http.Request, error) {
+		// Create and modify HTTP request before sending
+		req, err := http.NewRequestWithContext(ctx, method, urlPath, bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		if contentType != "" {
+			req.Header.Set("Content-Type", contentType)
 		}
-		reqBody = strings.NewReader(frm.Encode())
-	}
-
-	// Create and modify HTTP request before sending
-	req, err := http.NewRequest(method, urlPath, reqBody)
-	if err != nil {
-		return nil, err
-	}
 
-	// Set Client headers
-	for key, val := range clt.headers {
-		req.Header.Set(key, ToString(val))
-	}
+		// Set Client headers
+		for key, val := range clt.headers {
+			req.Header.Set(key, ToString(val))
+		}
 
-	// Set Custom headers
-	for key, val := range headers {
-		req.Header.Set(key, ToString(val))
-	}
+		// Set Custom headers
+		for key, val := range headers {
+			req.Header.Set(key, ToString(val))
+		}
 
-	if isGet {
-		q := req.URL.Query()
-		for key, val := range params {
-			q.Add(key, ToString(val))
+		if len(query) > 0 {
+			q := req.URL.Query()
+			for key, val := range query {
+				addQueryParam(q, key, val)
+			}
+			req.URL.RawQuery = q.Encode()
 		}
-		req.URL.RawQuery = q.Encode()
+		return req, nil
 	}
 
-	// Make request
-	response, err := clt.client.Do(req)
+	// Make request, retrying according to the retry policy
+	response, err := clt.do(ctx, newRequest)
 	if err != nil {
 		return nil, err
 	}
 
-	// Handle response
-	defer response.Body.Close()
-
-	responseData, err := ioutil.ReadAll(response.Body)
-	if err != nil {
-		return nil, err
+	if !isSuccess(response) {
+		defer response.Body.Close()
+		responseData, err := ioutil.ReadAll(response.Body)
+		if err != nil {
+			return nil, err
+		}
+		return nil, newAppwriteError(response, responseData)
 	}
 
-	return responseData, nil
+	return response, nil
 }
@@ -0,0 +1,250 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"strconv"
+	"strings"
+	"unicode"
+
+	appwrite "github.com/appwrite/sdk-for-go"
+	"github.com/appwrite/sdk-for-go/schema"
+)
+
+// reserved are the names promoted from the embedded appwrite.DocumentMeta,
+// which attributes can't use
+var reserved = map[string]bool{
+	"DocumentMeta": true,
+	"Id":           true,
+	"CreatedAt":    true,
+	"UpdatedAt":    true,
+	"Permissions":  true,
+	"CollectionId": true,
+	"DatabaseId":   true,
+}
+
+// Generate returns the formatted source of the types and repositories of the
+// given collections of a database
+func Generate(pkg, databaseId string, collections []schema.CollectionSpec) ([]byte, error) {
+	g := &generator{names: map[string]string{"DatabaseId": "the DatabaseId constant"}}
+
+	g.printf("// Code generated by appwrite-gen. DO NOT EDIT.\n\n")
+	g.printf("package %s\n\n", pkg)
+	// Only the repositories use the imports
+	if len(collections) > 0 {
+		g.printf("import (\n\t\"context\"\n\n\tappwrite %q\n)\n\n", "github.com/appwrite/sdk-for-go")
+	}
+	g.printf("// DatabaseId is the id of the database the collections belong to\n")
+	g.printf("const DatabaseId = %q\n", databaseId)
+
+	for i := range collections {
+		if err := g.collection(&collections[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	src, err := format.Source(g.buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated code: %w", err)
+	}
+	return src, nil
+}
+
+type generator struct {
+	buf bytes.Buffer
+	// names maps the top-level identifiers declared so far to what declared them
+	names map[string]string
+}
+
+func (g *generator) printf(format string, args ...interface{}) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+// declare reserves a top-level identifier, failing when two collections or
+// attributes map to the same one
+func (g *generator) declare(name, owner string) error {
+	if other, ok := g.names[name]; ok {
+		return fmt.Errorf("%s and %s both generate the identifier %s", other, owner, name)
+	}
+	g.names[name] = owner
+	return nil
+}
+
+func (g *generator) collection(collection *schema.CollectionSpec) error {
+	owner := fmt.Sprintf("collection %q", collection.Id)
+	name := identifier(collection.Id)
+	for _, decl := range []string{name, name + "CollectionId", name + "Repository", "New" + name + "Repository"} {
+		if err := g.declare(decl, owner); err != nil {
+			return err
+		}
+	}
+
+	g.printf("\n// %sCollectionId is the id of the %s collection\n", name, collection.Id)
+	g.printf("const %sCollectionId = %q\n", name, collection.Id)
+
+	fields := make([]string, 0, len(collection.Attributes))
+	used := map[string]bool{}
+	for field := range reserved {
+		used[field] = true
+	}
+	for i := range collection.Attributes {
+		attribute := &collection.Attributes[i]
+		field := unique(identifier(attribute.Key), used)
+
+		typ := goType(attribute)
+		if attribute.Type == schema.TypeEnum {
+			typ = name + field
+			if err := g.enum(typ, attribute, fmt.Sprintf("attribute %q of %s", attribute.Key, owner)); err != nil {
+				return err
+			}
+		}
+
+		tag := attribute.Key
+		switch {
+		case attribute.Array:
+			typ = "[]" + typ
+			tag += ",omitempty"
+		case attribute.Type == schema.TypeRelationship:
+			tag += ",omitempty"
+		case !attribute.Required:
+			typ = "*" + typ
+			tag += ",omitempty"
+		}
+		fields = append(fields, fmt.Sprintf("\t%s %s `json:%q`\n", field, typ, tag))
+	}
+
+	display := collection.Name
+	if display == "" {
+		display = collection.Id
+	}
+	g.printf("\n// %s is a document of the %s collection\n", name, display)
+	g.printf("type %s struct {\n\tappwrite.DocumentMeta\n", name)
+	for _, field := range fields {
+		g.printf("%s", field)
+	}
+	g.printf("}\n")
+
+	g.printf(repository, name, collection.Id)
+	return nil
+}
+
+// enum declares the string type of an enum attribute and a constant for each
+// of its elements
+func (g *generator) enum(typ string, attribute *schema.AttributeSpec, owner string) error {
+	if err := g.declare(typ, owner); err != nil {
+		return err
+	}
+	g.printf("\n// %s is an element of the %s enum\n", typ, attribute.Key)
+	g.printf("type %s string\n\n", typ)
+	g.printf("// Elements of %s\nconst (\n", typ)
+	used := map[string]bool{}
+	for _, element := range attribute.Elements {
+		suffix := identifier(element)
+		if suffix == "X" {
+			suffix = "Value"
+		}
+		constant := typ + unique(suffix, used)
+		if err := g.declare(constant, owner); err != nil {
+			return err
+		}
+		g.printf("\t%s %s = %q\n", constant, typ, element)
+	}
+	g.printf(")\n")
+	return nil
+}
+
+// repository is the template of the repository of a collection, taking the
+// type name and the collection id
+const repository = `
+// %[1]sRepository reads and writes documents of the %[2]s collection
+type %[1]sRepository struct {
+	DB         *appwrite.Database
+	DatabaseId string
+}
+
+// New%[1]sRepository returns a repository of the %[2]s collection of DatabaseId
+func New%[1]sRepository(db *appwrite.Database) *%[1]sRepository {
+	return &%[1]sRepository{DB: db, DatabaseId: DatabaseId}
+}
+
+// Get gets a document by id
+func (r *%[1]sRepository) Get(ctx context.Context, documentId string) (*%[1]s, error) {
+	return appwrite.GetDocumentAsContext[%[1]s](ctx, r.DB, r.DatabaseId, %[1]sCollectionId, documentId)
+}
+
+// List lists the documents matching queries
+func (r *%[1]sRepository) List(ctx context.Context, queries []string) (*appwrite.TypedDocumentList[%[1]s], error) {
+	return appwrite.ListDocumentsAsContext[%[1]s](ctx, r.DB, r.DatabaseId, %[1]sCollectionId, queries)
+}
+
+// Create creates a document out of value
+func (r *%[1]sRepository) Create(ctx context.Context, documentId string, value %[1]s, permissions []string) (*%[1]s, error) {
+	return appwrite.CreateDocumentFromContext(ctx, r.DB, r.DatabaseId, %[1]sCollectionId, documentId, value, permissions)
+}
+
+// Update updates a document with the attributes of value, leaving nil
+// optional attributes and, when permissions is nil, permissions untouched
+func (r *%[1]sRepository) Update(ctx context.Context, documentId string, value %[1]s, permissions []string) (*%[1]s, error) {
+	return appwrite.UpdateDocumentFromContext(ctx, r.DB, r.DatabaseId, %[1]sCollectionId, documentId, value, permissions)
+}
+
+// Delete deletes a document
+func (r *%[1]sRepository) Delete(ctx context.Context, documentId string) error {
+	return r.DB.DeleteDocumentContext(ctx, r.DatabaseId, %[1]sCollectionId, documentId)
+}
+`
+
+// goType returns the Go type of a single value of an attribute
+func goType(attribute *schema.AttributeSpec) string {
+	switch attribute.Type {
+	case schema.TypeInteger:
+		return "int64"
+	case schema.TypeFloat:
+		return "float64"
+	case schema.TypeBoolean:
+		return "bool"
+	case schema.TypeRelationship:
+		// Related documents come either as ids or as nested documents
+		switch attribute.RelationType {
+		case appwrite.RelationOneToMany, appwrite.RelationManyToMany:
+			return "[]interface{}"
+		}
+		return "interface{}"
+	}
+	return "string"
+}
+
+// identifier turns an id, key or enum element into an exported Go
+// identifier, such as "created_at" into "CreatedAt"
+func identifier(s string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range s {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	name := b.String()
+	if name == "" || !unicode.IsLetter([]rune(name)[0]) {
+		name = "X" + name
+	}
+	return name
+}
+
+// unique returns name, suffixed with a number when it's already used, and
+// marks it as used
+func unique(name string, used map[string]bool) string {
+	candidate := name
+	for i := 2; used[candidate]; i++ {
+		candidate = name + strconv.Itoa(i)
+	}
+	used[candidate] = true
+	return candidate
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/appwrite/sdk-for-go/schema"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files")
+
+func TestGenerate(t *testing.T) {
+	tests := []struct {
+		name     string
+		database string
+		golden   string
+	}{
+		{"collections", "main", "testdata/blog.golden"},
+		{"no collections", "empty", "testdata/empty.golden"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			specs, err := fromFile("testdata/blog.json", tt.database, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			src, err := Generate("models", tt.database, specs)
+			if err != nil {
+				t.Fatalf("Generate: %v", err)
+			}
+
+			if *update {
+				if err := os.WriteFile(tt.golden, src, 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			want, err := os.ReadFile(tt.golden)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(src, want) {
+				t.Errorf("generated code differs from %s, rerun with -update to accept it:\n%s", tt.golden, src)
+			}
+
+			compile(t, src)
+		})
+	}
+}
+
+func TestGenerateCollisions(t *testing.T) {
+	tests := []struct {
+		name        string
+		collections []schema.CollectionSpec
+		want        string
+	}{
+		{"database constant", []schema.CollectionSpec{{Id: "database_id"}},
+			`the DatabaseId constant and collection "database_id" both generate the identifier DatabaseId`},
+		{"two collections", []schema.CollectionSpec{{Id: "blog-posts"}, {Id: "blog_posts"}},
+			`collection "blog-posts" and collection "blog_posts" both generate the identifier BlogPosts`},
+		{"enum and collection", []schema.CollectionSpec{
+			{Id: "posts", Attributes: []schema.AttributeSpec{{Key: "status", Type: schema.TypeEnum, Elements: []string{"draft"}}}},
+			{Id: "posts_status"},
+		}, `attribute "status" of collection "posts" and collection "posts_status" both generate the identifier PostsStatus`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Generate("models", "main", tt.collections)
+			if err == nil || err.Error() != tt.want {
+				t.Errorf("Generate error = %v, want %s", err, tt.want)
+			}
+		})
+	}
+}
+
+// compile builds src in a module depending on this one, so that generated
+// code that doesn't compile fails the test
+func compile(t *testing.T, src []byte) {
+	t.Helper()
+	if testing.Short() {
+		t.Skip("compiling generated code is skipped in short mode")
+	}
+	root, err := filepath.Abs("../..")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sum, err := os.ReadFile(filepath.Join(root, "go.sum"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	mod := "module example.com/models\n\ngo 1.23\n\n" +
+		"require github.com/appwrite/sdk-for-go v0.0.0\n\n" +
+		"replace github.com/appwrite/sdk-for-go => " + root + "\n"
+	files := map[string][]byte{"go.mod": []byte(mod), "go.sum": sum, "models.go": src}
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cmd := exec.Command("go", "vet", ".")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Errorf("generated code doesn't compile: %v\n%s", err, strings.TrimSpace(string(out)))
+	}
+}
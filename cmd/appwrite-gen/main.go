@@ -0,0 +1,141 @@
+// Command appwrite-gen generates Go types mirroring Appwrite collections.
+//
+// For every collection it emits a struct with json tags, constants for the
+// elements of enum attributes and a repository wrapping the Database service
+// with typed Get, List, Create, Update and Delete methods. Optional
+// attributes are generated as pointers.
+//
+// Collections are read from a schema file exported with the schema package:
+//
+//	appwrite-gen -schema schema.json -database main -package models -out models.go
+//
+// or from a live project:
+//
+//	appwrite-gen -endpoint https://cloud.appwrite.io/v1 -project <id> -key <key> \
+//		-database main -collections posts,authors -package models -out models.go
+//
+// The API key may also be given through the APPWRITE_KEY environment variable.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	appwrite "github.com/appwrite/sdk-for-go"
+	"github.com/appwrite/sdk-for-go/schema"
+)
+
+func main() {
+	var (
+		schemaPath  = flag.String("schema", "", "schema file to read collections from")
+		endpoint    = flag.String("endpoint", "", "Appwrite endpoint to read collections from")
+		project     = flag.String("project", "", "project id")
+		key         = flag.String("key", os.Getenv("APPWRITE_KEY"), "API key, defaults to $APPWRITE_KEY")
+		databaseId  = flag.String("database", "", "database id")
+		collections = flag.String("collections", "", "comma separated collection ids, all collections when empty")
+		pkg         = flag.String("package", "models", "package name of the generated code")
+		out         = flag.String("out", "", "output file, standard output when empty")
+	)
+	flag.Parse()
+
+	if *databaseId == "" || (*schemaPath == "") == (*endpoint == "") {
+		fmt.Fprintln(os.Stderr, "appwrite-gen: -database and one of -schema or -endpoint are required")
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	var ids []string
+	if *collections != "" {
+		ids = strings.Split(*collections, ",")
+	}
+
+	var (
+		specs []schema.CollectionSpec
+		err   error
+	)
+	if *schemaPath != "" {
+		specs, err = fromFile(*schemaPath, *databaseId, ids)
+	} else {
+		client := appwrite.NewClient()
+		client.SetEndpoint(*endpoint)
+		client.SetProject(*project)
+		client.SetKey(*key)
+		specs, err = fromProject(context.Background(), appwrite.NewDatabase(client), *databaseId, ids)
+	}
+	if err != nil {
+		fatal(err)
+	}
+
+	src, err := Generate(*pkg, *databaseId, specs)
+	if err != nil {
+		fatal(err)
+	}
+	if *out == "" {
+		_, err = os.Stdout.Write(src)
+	} else {
+		err = os.WriteFile(*out, src, 0o644)
+	}
+	if err != nil {
+		fatal(err)
+	}
+}
+
+// fromFile reads the collections of a database from a schema file
+func fromFile(path, databaseId string, ids []string) ([]schema.CollectionSpec, error) {
+	spec, err := schema.Load(path)
+	if err != nil {
+		return nil, err
+	}
+	for _, database := range spec.Databases {
+		if database.Id != databaseId {
+			continue
+		}
+		if len(ids) == 0 {
+			return database.Collections, nil
+		}
+		byId := map[string]schema.CollectionSpec{}
+		for _, collection := range database.Collections {
+			byId[collection.Id] = collection
+		}
+		var specs []schema.CollectionSpec
+		for _, id := range ids {
+			collection, ok := byId[id]
+			if !ok {
+				return nil, fmt.Errorf("collection %q not found in %s", id, path)
+			}
+			specs = append(specs, collection)
+		}
+		return specs, nil
+	}
+	return nil, fmt.Errorf("database %q not found in %s", databaseId, path)
+}
+
+// fromProject reads the collections of a database from a live project
+func fromProject(ctx context.Context, db appwrite.Database, databaseId string, ids []string) ([]schema.CollectionSpec, error) {
+	var specs []schema.CollectionSpec
+	if len(ids) == 0 {
+		for collection, err := range db.AllCollections(ctx, databaseId, nil, 100) {
+			if err != nil {
+				return nil, err
+			}
+			specs = append(specs, schema.FromCollection(collection))
+		}
+		return specs, nil
+	}
+	for _, id := range ids {
+		collection, err := db.GetCollectionContext(ctx, databaseId, id)
+		if err != nil {
+			return nil, err
+		}
+		specs = append(specs, schema.FromCollection(*collection))
+	}
+	return specs, nil
+}
+
+func fatal(err error) {
+	fmt.Fprintln(os.Stderr, "appwrite-gen:", err)
+	os.Exit(1)
+}
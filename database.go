@@ -1,7 +1,10 @@
 package appwrite
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"fmt"
 	"strings"
 )
 
@@ -23,6 +26,7 @@ type DatabaseObject struct {
 	Name      string `json:"name"`
 	CreatedAt string `json:"$createdAt"`
 	UpdatedAt string `json:"$updatedAt"`
+	Enabled   bool   `json:"enabled"`
 }
 
 type DatabaseList struct {
@@ -31,11 +35,34 @@ type DatabaseList struct {
 }
 
 type AttributeOptions struct {
-	Key      string `json:"key"`
-	Type     string `json:"type"`
-	Status   string `json:"status"`
-	Required bool   `json:"required"`
-	Array    bool   `json:"array"`
+	Key      string      `json:"key"`
+	Type     string      `json:"type"`
+	Status   string      `json:"status"`
+	Error    string      `json:"error,omitempty"`
+	Required bool        `json:"required"`
+	Array    bool        `json:"array"`
+	Size     int         `json:"size,omitempty"`
+	Default  interface{} `json:"default,omitempty"`
+	Min      json.Number `json:"min,omitempty"`
+	Max      json.Number `json:"max,omitempty"`
+	Elements []string    `json:"elements,omitempty"`
+	Format   string      `json:"format,omitempty"`
+
+	RelatedCollection string `json:"relatedCollection,omitempty"`
+	RelationType      string `json:"relationType,omitempty"`
+	TwoWay            bool   `json:"twoWay,omitempty"`
+	TwoWayKey         string `json:"twoWayKey,omitempty"`
+	OnDelete          string `json:"onDelete,omitempty"`
+	Side              string `json:"side,omitempty"`
+}
+
+// UnmarshalJSON decodes a numeric Default as a json.Number, so that 64-bit
+// integer defaults aren't rounded
+func (options *AttributeOptions) UnmarshalJSON(data []byte) error {
+	type plain AttributeOptions
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	return dec.Decode((*plain)(options))
 }
 
 type Attribute struct {
@@ -43,11 +70,12 @@ type Attribute struct {
 }
 
 type Index struct {
-	Key        string   `json:"key"`
-	Type       string   `json:"type"`
-	Status     string   `json:"status"`
-	Attributes []string `json:"attributes"`
-	Orders     []string `json:"orders"`
+	Key        string       `json:"key"`
+	Type       IndexType    `json:"type"`
+	Status     string       `json:"status"`
+	Error      string       `json:"error,omitempty"`
+	Attributes []string     `json:"attributes"`
+	Orders     []IndexOrder `json:"orders"`
 }
 
 type Collection struct {
@@ -64,13 +92,13 @@ type Collection struct {
 }
 
 type Document struct {
-	Fields       map[string]interface{}
-	Id           string   `json:"$id"`
-	CreatedAt    string   `json:"$createdAt"`
-	UpdatedAt    string   `json:"$updatedAt"`
-	Permissions  []string `json:"permissions"`
-	CollectionId string   `json:"$collectionId"`
-	DatabaseId   string   `json:"$databaseId"`
+	Fields       map[string]interface{} `json:"-"`
+	Id           string                 `json:"$id"`
+	CreatedAt    string                 `json:"$createdAt"`
+	UpdatedAt    string                 `json:"$updatedAt"`
+	Permissions  []string               `json:"$permissions"`
+	CollectionId string                 `json:"$collectionId"`
+	DatabaseId   string                 `json:"$databaseId"`
 }
 
 type AttributeList struct {
@@ -78,6 +106,11 @@ type AttributeList struct {
 	Attributes []Attribute `json:"attributes"`
 }
 
+type IndexList struct {
+	Total   int     `json:"total"`
+	Indexes []Index `json:"indexes"`
+}
+
 type DocumentList struct {
 	Total     int        `json:"total"`
 	Documents []Document `json:"documents"`
@@ -89,13 +122,18 @@ type CollectionList struct {
 }
 
 func (srv *Database) ListDatabases(Search string, Queries []string) (*DatabaseList, error) {
+	return srv.ListDatabasesContext(context.Background(), Search, Queries)
+}
+
+// ListDatabasesContext is like ListDatabases but cancels the request when ctx is done.
+func (srv *Database) ListDatabasesContext(ctx context.Context, Search string, Queries []string) (*DatabaseList, error) {
 	path := "/databases"
 	params := map[string]interface{}{
 		"search":  Search,
 		"queries": Queries,
 	}
 
-	resp, err := srv.Client.CallAPI("GET", path, srv.Client.headers, params)
+	resp, err := srv.Client.CallAPIContext(ctx, "GET", path, srv.Client.headers, params)
 	if err != nil {
 		return nil, err
 	}
@@ -107,11 +145,69 @@ func (srv *Database) ListDatabases(Search string, Queries []string) (*DatabaseLi
 }
 
 func (srv *Database) GetDatabase(databaseId string) (*DatabaseObject, error) {
-	r := strings.NewReplacer("{databaseId}", databaseId)
+	return srv.GetDatabaseContext(context.Background(), databaseId)
+}
+
+// GetDatabaseContext is like GetDatabase but cancels the request when ctx is done.
+func (srv *Database) GetDatabaseContext(ctx context.Context, databaseId string) (*DatabaseObject, error) {
+	r := pathReplacer("{databaseId}", databaseId)
 	path := r.Replace("/databases/{databaseId}")
 	params := map[string]interface{}{}
 
-	resp, err := srv.Client.CallAPI("GET", path, srv.Client.headers, params)
+	resp, err := srv.Client.CallAPIContext(ctx, "GET", path, srv.Client.headers, params)
+	if err != nil {
+		return nil, err
+	}
+	var result DatabaseObject
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// CreateDatabase create a new Database.
+func (srv *Database) CreateDatabase(databaseId, Name string, Enabled bool) (*DatabaseObject, error) {
+	return srv.CreateDatabaseContext(context.Background(), databaseId, Name, Enabled)
+}
+
+// CreateDatabaseContext is like CreateDatabase but cancels the request when ctx is done.
+func (srv *Database) CreateDatabaseContext(ctx context.Context, databaseId, Name string, Enabled bool) (*DatabaseObject, error) {
+	if err := validateId("databaseId", databaseId); err != nil {
+		return nil, err
+	}
+	path := "/databases"
+	params := map[string]interface{}{
+		"databaseId": databaseId,
+		"name":       Name,
+		"enabled":    Enabled,
+	}
+
+	resp, err := srv.Client.CallAPIContext(ctx, "POST", path, srv.Client.headers, params)
+	if err != nil {
+		return nil, err
+	}
+	var result DatabaseObject
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// UpdateDatabase update a database by its unique ID.
+func (srv *Database) UpdateDatabase(databaseId, Name string, Enabled bool) (*DatabaseObject, error) {
+	return srv.UpdateDatabaseContext(context.Background(), databaseId, Name, Enabled)
+}
+
+// UpdateDatabaseContext is like UpdateDatabase but cancels the request when ctx is done.
+func (srv *Database) UpdateDatabaseContext(ctx context.Context, databaseId, Name string, Enabled bool) (*DatabaseObject, error) {
+	r := pathReplacer("{databaseId}", databaseId)
+	path := r.Replace("/databases/{databaseId}")
+	params := map[string]interface{}{
+		"name":    Name,
+		"enabled": Enabled,
+	}
+
+	resp, err := srv.Client.CallAPIContext(ctx, "PUT", path, srv.Client.headers, params)
 	if err != nil {
 		return nil, err
 	}
@@ -122,12 +218,33 @@ func (srv *Database) GetDatabase(databaseId string) (*DatabaseObject, error) {
 	return &result, nil
 }
 
+// DeleteDatabase delete a database by its unique ID. All the collections and
+// documents it holds are deleted with it.
+func (srv *Database) DeleteDatabase(databaseId string) error {
+	return srv.DeleteDatabaseContext(context.Background(), databaseId)
+}
+
+// DeleteDatabaseContext is like DeleteDatabase but cancels the request when ctx is done.
+func (srv *Database) DeleteDatabaseContext(ctx context.Context, databaseId string) error {
+	r := pathReplacer("{databaseId}", databaseId)
+	path := r.Replace("/databases/{databaseId}")
+	params := map[string]interface{}{}
+
+	_, err := srv.Client.CallAPIContext(ctx, "DELETE", path, srv.Client.headers, params)
+	return err
+}
+
 // ListCollections get a list of all the user collections. You can use the
 // query params to filter your results. On admin mode, this endpoint will
 // return a list of all of the project collections. [Learn more about
 // different API modes](/docs/admin).
 func (srv *Database) ListCollections(databaseId, Search string, Queries []string) (*CollectionList, error) {
-	r := strings.NewReplacer("{databaseId}", databaseId)
+	return srv.ListCollectionsContext(context.Background(), databaseId, Search, Queries)
+}
+
+// ListCollectionsContext is like ListCollections but cancels the request when ctx is done.
+func (srv *Database) ListCollectionsContext(ctx context.Context, databaseId, Search string, Queries []string) (*CollectionList, error) {
+	r := pathReplacer("{databaseId}", databaseId)
 	path := r.Replace("/databases/{databaseId}/collections")
 
 	params := map[string]interface{}{
@@ -135,7 +252,7 @@ func (srv *Database) ListCollections(databaseId, Search string, Queries []string
 		"queries": Queries,
 	}
 
-	resp, err := srv.Client.CallAPI("GET", path, srv.Client.headers, params)
+	resp, err := srv.Client.CallAPIContext(ctx, "GET", path, srv.Client.headers, params)
 	if err != nil {
 		return nil, err
 	}
@@ -148,29 +265,51 @@ func (srv *Database) ListCollections(databaseId, Search string, Queries []string
 
 }
 
-// CreateCollection create a new Collection.
-func (srv *Database) CreateCollection(Name string, Read []interface{}, Write []interface{}, Rules []interface{}) (map[string]interface{}, error) {
-	path := "/database/collections"
+// CreateCollection create a new Collection in the database.
+func (srv *Database) CreateCollection(databaseId, collectionId, Name string, Permissions []string, DocumentSecurity bool) (*Collection, error) {
+	return srv.CreateCollectionContext(context.Background(), databaseId, collectionId, Name, Permissions, DocumentSecurity)
+}
+
+// CreateCollectionContext is like CreateCollection but cancels the request when ctx is done.
+func (srv *Database) CreateCollectionContext(ctx context.Context, databaseId, collectionId, Name string, Permissions []string, DocumentSecurity bool) (*Collection, error) {
+	if err := validateId("collectionId", collectionId); err != nil {
+		return nil, err
+	}
+	r := pathReplacer("{databaseId}", databaseId)
+	path := r.Replace("/databases/{databaseId}/collections")
 
 	params := map[string]interface{}{
-		"name":  Name,
-		"read":  Read,
-		"write": Write,
-		"rules": Rules,
+		"collectionId":     collectionId,
+		"name":             Name,
+		"permissions":      Permissions,
+		"documentSecurity": DocumentSecurity,
 	}
 
-	return srv.Client.Call("POST", path, nil, params)
+	resp, err := srv.Client.CallAPIContext(ctx, "POST", path, srv.Client.headers, params)
+	if err != nil {
+		return nil, err
+	}
+	var result Collection
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
 }
 
 // GetCollection get collection by its unique ID. This endpoint response
 // returns a JSON object with the collection metadata.
 func (srv *Database) GetCollection(databaseId, collectionId string) (*Collection, error) {
-	r := strings.NewReplacer("{databaseId}", databaseId, "{collectionId}", collectionId)
+	return srv.GetCollectionContext(context.Background(), databaseId, collectionId)
+}
+
+// GetCollectionContext is like GetCollection but cancels the request when ctx is done.
+func (srv *Database) GetCollectionContext(ctx context.Context, databaseId, collectionId string) (*Collection, error) {
+	r := pathReplacer("{databaseId}", databaseId, "{collectionId}", collectionId)
 	path := r.Replace("/databases/{databaseId}/collections/{collectionId}")
 
 	params := map[string]interface{}{}
 
-	resp, err := srv.Client.CallAPI("GET", path, srv.Client.headers, params)
+	resp, err := srv.Client.CallAPIContext(ctx, "GET", path, srv.Client.headers, params)
 	if err != nil {
 		return nil, err
 	}
@@ -182,140 +321,231 @@ func (srv *Database) GetCollection(databaseId, collectionId string) (*Collection
 }
 
 // UpdateCollection update collection by its unique ID.
-func (srv *Database) UpdateCollection(CollectionId string, Name string, Read []interface{}, Write []interface{}, Rules []interface{}) (map[string]interface{}, error) {
-	r := strings.NewReplacer("{collectionId}", CollectionId)
-	path := r.Replace("/database/collections/{collectionId}")
+func (srv *Database) UpdateCollection(databaseId, collectionId, Name string, Permissions []string, DocumentSecurity bool, Enabled bool) (*Collection, error) {
+	return srv.UpdateCollectionContext(context.Background(), databaseId, collectionId, Name, Permissions, DocumentSecurity, Enabled)
+}
+
+// UpdateCollectionContext is like UpdateCollection but cancels the request when ctx is done.
+func (srv *Database) UpdateCollectionContext(ctx context.Context, databaseId, collectionId, Name string, Permissions []string, DocumentSecurity bool, Enabled bool) (*Collection, error) {
+	r := pathReplacer("{databaseId}", databaseId, "{collectionId}", collectionId)
+	path := r.Replace("/databases/{databaseId}/collections/{collectionId}")
 
 	params := map[string]interface{}{
-		"name":  Name,
-		"read":  Read,
-		"write": Write,
-		"rules": Rules,
+		"name":             Name,
+		"permissions":      Permissions,
+		"documentSecurity": DocumentSecurity,
+		"enabled":          Enabled,
 	}
 
-	return srv.Client.Call("PUT", path, nil, params)
+	resp, err := srv.Client.CallAPIContext(ctx, "PUT", path, srv.Client.headers, params)
+	if err != nil {
+		return nil, err
+	}
+	var result Collection
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
 }
 
 // DeleteCollection delete a collection by its unique ID. Only users with
 // write permissions have access to delete this resource.
-func (srv *Database) DeleteCollection(CollectionId string) (map[string]interface{}, error) {
-	r := strings.NewReplacer("{collectionId}", CollectionId)
-	path := r.Replace("/database/collections/{collectionId}")
+func (srv *Database) DeleteCollection(databaseId, collectionId string) error {
+	return srv.DeleteCollectionContext(context.Background(), databaseId, collectionId)
+}
+
+// DeleteCollectionContext is like DeleteCollection but cancels the request when ctx is done.
+func (srv *Database) DeleteCollectionContext(ctx context.Context, databaseId, collectionId string) error {
+	r := pathReplacer("{databaseId}", databaseId, "{collectionId}", collectionId)
+	path := r.Replace("/databases/{databaseId}/collections/{collectionId}")
 
 	params := map[string]interface{}{}
 
-	return srv.Client.Call("DELETE", path, nil, params)
+	_, err := srv.Client.CallAPIContext(ctx, "DELETE", path, srv.Client.headers, params)
+	return err
 }
 
 // ListDocuments get a list of all the user documents. You can use the query
 // params to filter your results. On admin mode, this endpoint will return a
 // list of all of the project documents. [Learn more about different API
-// modes](/docs/admin).
-func (srv *Database) ListDocuments(databaseId, collectionId string, Filters []interface{}, Offset int, Limit int, OrderField string, OrderType string, OrderCast string, Search string, First int, Last int) (*DocumentList, error) {
+// modes](/docs/admin). Queries can be built with the query package.
+func (srv *Database) ListDocuments(databaseId, collectionId string, Queries []string) (*DocumentList, error) {
+	return srv.ListDocumentsContext(context.Background(), databaseId, collectionId, Queries)
+}
 
-	r := strings.NewReplacer("{databaseId}", databaseId, "{collectionId}", collectionId)
+// ListDocumentsContext is like ListDocuments but cancels the request when ctx is done.
+func (srv *Database) ListDocumentsContext(ctx context.Context, databaseId, collectionId string, Queries []string) (*DocumentList, error) {
+	r := pathReplacer("{databaseId}", databaseId, "{collectionId}", collectionId)
 	path := r.Replace("/databases/{databaseId}/collections/{collectionId}/documents")
 
 	params := map[string]interface{}{
-		"filters":     Filters,
-		"offset":      Offset,
-		"limit":       Limit,
-		"order-field": OrderField,
-		"order-type":  OrderType,
-		"order-cast":  OrderCast,
-		"search":      Search,
-		"first":       First,
-		"last":        Last,
-	}
-
-	resp, err := srv.Client.CallAPI("GET", path, srv.Client.headers, params)
-	if err != nil {
-		return nil, err
+		"queries": Queries,
 	}
-	path = r.Replace("/databases/{databaseId}/collections/{collectionId}/attributes")
-	resp2, err := srv.Client.CallAPI("GET", path, srv.Client.headers, params)
+
+	resp, err := srv.Client.CallAPIContext(ctx, "GET", path, srv.Client.headers, params)
 	if err != nil {
 		return nil, err
 	}
-	var attributes AttributeList
-	if err := json.Unmarshal(resp2, &attributes); err != nil {
-		return nil, err
-	}
-
 	var result DocumentList
-	var doc map[string]interface{}
-	json.Unmarshal(resp, &doc)
-
 	if err := json.Unmarshal(resp, &result); err != nil {
 		return nil, err
 	}
-
-	for id := range result.Documents {
-		result.Documents[id].Fields = make(map[string]interface{})
-		for _, attribute := range attributes.Attributes {
-			docs := doc["documents"].([]interface{})
-			doc := docs[id].(map[string]interface{})
-			val := doc[attribute.Key]
-			if val != nil {
-				result.Documents[id].Fields[attribute.Key] = val
-			} else {
-				result.Documents[id].Fields[attribute.Key] = nil
-			}
-		}
-	}
 	return &result, nil
 }
 
 // CreateDocument create a new Document.
-func (srv *Database) CreateDocument(CollectionId string, Data map[string]interface{}, Read []interface{}, Write []interface{}, ParentDocument string, ParentProperty string, ParentPropertyType string) (map[string]interface{}, error) {
-	r := strings.NewReplacer("{collectionId}", CollectionId)
-	path := r.Replace("/database/collections/{collectionId}/documents")
+func (srv *Database) CreateDocument(databaseId, collectionId, documentId string, Data map[string]interface{}, Permissions []string) (*Document, error) {
+	return srv.CreateDocumentContext(context.Background(), databaseId, collectionId, documentId, Data, Permissions)
+}
+
+// CreateDocumentContext is like CreateDocument but cancels the request when ctx is done.
+func (srv *Database) CreateDocumentContext(ctx context.Context, databaseId, collectionId, documentId string, Data map[string]interface{}, Permissions []string) (*Document, error) {
+	if err := validateId("documentId", documentId); err != nil {
+		return nil, err
+	}
+	r := pathReplacer("{databaseId}", databaseId, "{collectionId}", collectionId)
+	path := r.Replace("/databases/{databaseId}/collections/{collectionId}/documents")
 
 	params := map[string]interface{}{
-		"data":               Data,
-		"read":               Read,
-		"write":              Write,
-		"parentDocument":     ParentDocument,
-		"parentProperty":     ParentProperty,
-		"parentPropertyType": ParentPropertyType,
+		"documentId":  documentId,
+		"data":        Data,
+		"permissions": Permissions,
 	}
 
-	return srv.Client.Call("POST", path, nil, params)
+	resp, err := srv.Client.CallAPIContext(ctx, "POST", path, srv.Client.headers, params)
+	if err != nil {
+		return nil, err
+	}
+	return decodeDocument(resp)
 }
 
 // GetDocument get document by its unique ID. This endpoint response returns a
 // JSON object with the document data.
-func (srv *Database) GetDocument(CollectionId string, DocumentId string) (map[string]interface{}, error) {
-	r := strings.NewReplacer("{collectionId}", CollectionId, "{documentId}", DocumentId)
-	path := r.Replace("/database/collections/{collectionId}/documents/{documentId}")
+func (srv *Database) GetDocument(databaseId, collectionId, documentId string) (*Document, error) {
+	return srv.GetDocumentContext(context.Background(), databaseId, collectionId, documentId)
+}
+
+// GetDocumentContext is like GetDocument but cancels the request when ctx is done.
+func (srv *Database) GetDocumentContext(ctx context.Context, databaseId, collectionId, documentId string) (*Document, error) {
+	r := pathReplacer("{databaseId}", databaseId, "{collectionId}", collectionId, "{documentId}", documentId)
+	path := r.Replace("/databases/{databaseId}/collections/{collectionId}/documents/{documentId}")
 
 	params := map[string]interface{}{}
 
-	return srv.Client.Call("GET", path, nil, params)
+	resp, err := srv.Client.CallAPIContext(ctx, "GET", path, srv.Client.headers, params)
+	if err != nil {
+		return nil, err
+	}
+	return decodeDocument(resp)
+}
+
+// UpdateDocument update document by its unique ID. Only the attributes passed
+// in Data are updated, and Permissions is left untouched when nil.
+func (srv *Database) UpdateDocument(databaseId, collectionId, documentId string, Data map[string]interface{}, Permissions []string) (*Document, error) {
+	return srv.UpdateDocumentContext(context.Background(), databaseId, collectionId, documentId, Data, Permissions)
 }
 
-// UpdateDocument
-func (srv *Database) UpdateDocument(CollectionId string, DocumentId string, Data map[string]interface{}, Read []interface{}, Write []interface{}) (map[string]interface{}, error) {
-	r := strings.NewReplacer("{collectionId}", CollectionId, "{documentId}", DocumentId)
-	path := r.Replace("/database/collections/{collectionId}/documents/{documentId}")
+// UpdateDocumentContext is like UpdateDocument but cancels the request when ctx is done.
+func (srv *Database) UpdateDocumentContext(ctx context.Context, databaseId, collectionId, documentId string, Data map[string]interface{}, Permissions []string) (*Document, error) {
+	r := pathReplacer("{databaseId}", databaseId, "{collectionId}", collectionId, "{documentId}", documentId)
+	path := r.Replace("/databases/{databaseId}/collections/{collectionId}/documents/{documentId}")
 
 	params := map[string]interface{}{
-		"data":  Data,
-		"read":  Read,
-		"write": Write,
+		"data": Data,
+	}
+	if Permissions != nil {
+		params["permissions"] = Permissions
+	}
+
+	resp, err := srv.Client.CallAPIContext(ctx, "PATCH", path, srv.Client.headers, params)
+	if err != nil {
+		return nil, err
 	}
+	return decodeDocument(resp)
+}
 
-	return srv.Client.Call("PATCH", path, nil, params)
+// DeleteDocument delete document by its unique ID.
+func (srv *Database) DeleteDocument(databaseId, collectionId, documentId string) error {
+	return srv.DeleteDocumentContext(context.Background(), databaseId, collectionId, documentId)
 }
 
-// DeleteDocument delete document by its unique ID. This endpoint deletes only
-// the parent documents, his attributes and relations to other documents.
-// Child documents **will not** be deleted.
-func (srv *Database) DeleteDocument(CollectionId string, DocumentId string) (map[string]interface{}, error) {
-	r := strings.NewReplacer("{collectionId}", CollectionId, "{documentId}", DocumentId)
-	path := r.Replace("/database/collections/{collectionId}/documents/{documentId}")
+// DeleteDocumentContext is like DeleteDocument but cancels the request when ctx is done.
+func (srv *Database) DeleteDocumentContext(ctx context.Context, databaseId, collectionId, documentId string) error {
+	r := pathReplacer("{databaseId}", databaseId, "{collectionId}", collectionId, "{documentId}", documentId)
+	path := r.Replace("/databases/{databaseId}/collections/{collectionId}/documents/{documentId}")
 
 	params := map[string]interface{}{}
 
-	return srv.Client.Call("DELETE", path, nil, params)
+	_, err := srv.Client.CallAPIContext(ctx, "DELETE", path, srv.Client.headers, params)
+	return err
+}
+
+// decodeDocument decodes a document response
+func decodeDocument(data []byte) (*Document, error) {
+	var result Document
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// UnmarshalJSON decodes a document in a single pass, splitting the
+// $-prefixed system fields from the attributes collected into Fields.
+// Numbers are decoded as json.Number.
+func (doc *Document) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	*doc = Document{
+		Fields: make(map[string]interface{}),
+	}
+	for key, val := range raw {
+		var err error
+		switch key {
+		case "$id":
+			err = json.Unmarshal(val, &doc.Id)
+		case "$createdAt":
+			err = json.Unmarshal(val, &doc.CreatedAt)
+		case "$updatedAt":
+			err = json.Unmarshal(val, &doc.UpdatedAt)
+		case "$permissions":
+			err = json.Unmarshal(val, &doc.Permissions)
+		case "$collectionId":
+			err = json.Unmarshal(val, &doc.CollectionId)
+		case "$databaseId":
+			err = json.Unmarshal(val, &doc.DatabaseId)
+		default:
+			if strings.HasPrefix(key, "$") {
+				continue
+			}
+			// Keep numbers as json.Number so large integers aren't rounded
+			var field interface{}
+			dec := json.NewDecoder(bytes.NewReader(val))
+			dec.UseNumber()
+			err = dec.Decode(&field)
+			doc.Fields[key] = resolveRelated(field)
+		}
+		if err != nil {
+			return fmt.Errorf("appwrite: decoding document field %q: %w", key, err)
+		}
+	}
+	return nil
+}
+
+// MarshalJSON encodes a document the way the server returns it, with the
+// attributes in Fields next to the system fields
+func (doc Document) MarshalJSON() ([]byte, error) {
+	out := make(map[string]interface{}, len(doc.Fields)+6)
+	for key, val := range doc.Fields {
+		out[key] = val
+	}
+	out["$id"] = doc.Id
+	out["$createdAt"] = doc.CreatedAt
+	out["$updatedAt"] = doc.UpdatedAt
+	out["$permissions"] = doc.Permissions
+	out["$collectionId"] = doc.CollectionId
+	out["$databaseId"] = doc.DatabaseId
+	return json.Marshal(out)
 }
@@ -0,0 +1,74 @@
+package appwrite
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const bigInt = "9007199254740993" // 2^53 + 1, not representable as a float64
+
+func TestDocumentKeepsLargeIntegers(t *testing.T) {
+	data := `{"$id":"d1","$collectionId":"posts","views":` + bigInt + `,"ratio":0.1,"counts":[` + bigInt + `,1],"meta":{"n":` + bigInt + `}}`
+
+	var doc Document
+	if err := json.Unmarshal([]byte(data), &doc); err != nil {
+		t.Fatal(err)
+	}
+	if got := doc.Fields["views"]; got != json.Number(bigInt) {
+		t.Errorf("views = %#v, want json.Number(%s)", got, bigInt)
+	}
+	if got := doc.Fields["ratio"]; got != json.Number("0.1") {
+		t.Errorf("ratio = %#v, want json.Number(0.1)", got)
+	}
+
+	out, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`"views":` + bigInt, `"counts":[` + bigInt + `,1]`, `"meta":{"n":` + bigInt + `}`} {
+		if !strings.Contains(string(out), want) {
+			t.Errorf("marshalled document %s doesn't contain %s", out, want)
+		}
+	}
+}
+
+func TestGetDocumentKeepsLargeIntegers(t *testing.T) {
+	doc := `{"$id":"d1","views":` + bigInt + `,"counts":[` + bigInt + `]}`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/documents") {
+			fmt.Fprintf(w, `{"total":1,"documents":[%s]}`, doc)
+			return
+		}
+		fmt.Fprint(w, doc)
+	}))
+	defer srv.Close()
+
+	client := NewClient()
+	client.SetEndpoint(srv.URL)
+	db := NewDatabase(client)
+
+	got, err := db.GetDocument("main", "posts", "d1")
+	if err != nil {
+		t.Fatalf("GetDocument: %v", err)
+	}
+	list, err := db.ListDocuments("main", "posts", nil)
+	if err != nil {
+		t.Fatalf("ListDocuments: %v", err)
+	}
+	if len(list.Documents) != 1 {
+		t.Fatalf("listed %d documents, want 1", len(list.Documents))
+	}
+
+	for name, doc := range map[string]*Document{"GetDocument": got, "ListDocuments": &list.Documents[0]} {
+		if views := doc.Fields["views"]; views != json.Number(bigInt) {
+			t.Errorf("%s: views = %#v, want json.Number(%s)", name, views, bigInt)
+		}
+		if counts, _ := doc.Fields["counts"].([]interface{}); len(counts) != 1 || counts[0] != json.Number(bigInt) {
+			t.Errorf("%s: counts = %#v, want [json.Number(%s)]", name, doc.Fields["counts"], bigInt)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package appwrite
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime"
+)
+
+// FileContent is the streamed content of a file. It must be closed once
+// read.
+type FileContent struct {
+	io.ReadCloser
+	ContentType   string
+	ContentLength int64
+	ContentRange  string
+	Filename      string
+}
+
+// fetchFile opens a stream to a file content endpoint. A negative offset
+// requests the whole file, otherwise length bytes from offset are requested.
+func (srv *Storage) fetchFile(ctx context.Context, path string, bucketId, fileId string, offset, length int64) (*FileContent, error) {
+	r := pathReplacer("{bucketId}", bucketId, "{fileId}", fileId)
+	path = r.Replace(path)
+
+	headers := map[string]interface{}{}
+	if offset >= 0 {
+		headers["Range"] = fmt.Sprintf("bytes=%d-%d", offset, offset+length-1)
+	}
+
+	response, err := srv.Client.send(ctx, "GET", path, headers, nil, nil, "")
+	if err != nil {
+		return nil, err
+	}
+
+	content := &FileContent{
+		ReadCloser:    response.Body,
+		ContentType:   response.Header.Get("Content-Type"),
+		ContentLength: response.ContentLength,
+		ContentRange:  response.Header.Get("Content-Range"),
+	}
+	if _, params, err := mime.ParseMediaType(response.Header.Get("Content-Disposition")); err == nil {
+		content.Filename = params["filename"]
+	}
+	return content, nil
+}
@@ -0,0 +1,42 @@
+package appwrite
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetFileDownloadRange(t *testing.T) {
+	var ranges []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ranges = append(ranges, r.Header.Get("Range"))
+		w.WriteHeader(http.StatusPartialContent)
+		fmt.Fprint(w, "llo")
+	}))
+	defer srv.Close()
+
+	client := NewClient()
+	client.SetEndpoint(srv.URL)
+	storage := NewStorage(client)
+
+	for _, length := range []int64{0, -1} {
+		if _, err := storage.GetFileDownloadRange("b1", "f1", 2, length); err == nil {
+			t.Errorf("GetFileDownloadRange with length %d succeeded, want an error", length)
+		}
+	}
+	if len(ranges) != 0 {
+		t.Fatalf("invalid ranges sent requests %q", ranges)
+	}
+
+	content, err := storage.GetFileDownloadRange("b1", "f1", 2, 3)
+	if err != nil {
+		t.Fatalf("GetFileDownloadRange: %v", err)
+	}
+	defer content.Close()
+	data, _ := io.ReadAll(content)
+	if string(data) != "llo" || ranges[0] != "bytes=2-4" {
+		t.Errorf("got %q with Range %q, want \"llo\" with bytes=2-4", data, ranges[0])
+	}
+}
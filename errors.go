@@ -0,0 +1,68 @@
+package appwrite
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Sentinel errors that an *AppwriteError matches through errors.Is, based on
+// the HTTP status code returned by the server
+var (
+	ErrNotFound     = errors.New("appwrite: not found")
+	ErrUnauthorized = errors.New("appwrite: unauthorized")
+	ErrConflict     = errors.New("appwrite: conflict")
+	ErrRateLimited  = errors.New("appwrite: rate limited")
+)
+
+// AppwriteError is returned for every response with a non-2xx status code
+type AppwriteError struct {
+	StatusCode int    `json:"-"`
+	Message    string `json:"message"`
+	Code       int    `json:"code"`
+	Type       string `json:"type"`
+	Version    string `json:"version"`
+}
+
+// Error implements the error interface
+func (e *AppwriteError) Error() string {
+	msg := e.Message
+	if msg == "" {
+		msg = http.StatusText(e.StatusCode)
+	}
+	if e.Type != "" {
+		return fmt.Sprintf("appwrite: %s (%d %s)", msg, e.StatusCode, e.Type)
+	}
+	return fmt.Sprintf("appwrite: %s (%d)", msg, e.StatusCode)
+}
+
+// Is reports whether the error matches one of the sentinel errors
+func (e *AppwriteError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrConflict:
+		return e.StatusCode == http.StatusConflict
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	}
+	return false
+}
+
+// newAppwriteError builds an *AppwriteError from a failed response and its body
+func newAppwriteError(response *http.Response, body []byte) *AppwriteError {
+	appErr := &AppwriteError{}
+	if err := json.Unmarshal(body, appErr); err != nil || appErr.Message == "" {
+		appErr.Message = string(body)
+	}
+	appErr.StatusCode = response.StatusCode
+	return appErr
+}
+
+// isSuccess reports whether the response carries a 2xx status code
+func isSuccess(response *http.Response) bool {
+	return response.StatusCode >= 200 && response.StatusCode < 300
+}
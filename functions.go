@@ -1,8 +1,8 @@
 package appwrite
 
 import (
+	"context"
 	"encoding/json"
-	"strings"
 )
 
 // Functions service
@@ -96,13 +96,18 @@ type ExecutionListResponse struct {
 }
 
 func (srv *Function) ListFunctions(Search string, Queries []string) (*FunctionListResponse, error) {
+	return srv.ListFunctionsContext(context.Background(), Search, Queries)
+}
+
+// ListFunctionsContext is like ListFunctions but cancels the request when ctx is done.
+func (srv *Function) ListFunctionsContext(ctx context.Context, Search string, Queries []string) (*FunctionListResponse, error) {
 	path := "/functions"
 	params := map[string]interface{}{
 		"search":  Search,
 		"queries": Queries,
 	}
 
-	resp, err := srv.Client.CallAPI("GET", path, srv.Client.headers, params)
+	resp, err := srv.Client.CallAPIContext(ctx, "GET", path, srv.Client.headers, params)
 	if err != nil {
 		return nil, err
 	}
@@ -114,11 +119,16 @@ func (srv *Function) ListFunctions(Search string, Queries []string) (*FunctionLi
 }
 
 func (srv *Function) GetFunction(functionId string) (*FunctionObject, error) {
-	r := strings.NewReplacer("{functionId}", functionId)
+	return srv.GetFunctionContext(context.Background(), functionId)
+}
+
+// GetFunctionContext is like GetFunction but cancels the request when ctx is done.
+func (srv *Function) GetFunctionContext(ctx context.Context, functionId string) (*FunctionObject, error) {
+	r := pathReplacer("{functionId}", functionId)
 	path := r.Replace("/functions/{functionId}")
 	params := map[string]interface{}{}
 
-	resp, err := srv.Client.CallAPI("GET", path, srv.Client.headers, params)
+	resp, err := srv.Client.CallAPIContext(ctx, "GET", path, srv.Client.headers, params)
 	if err != nil {
 		return nil, err
 	}
@@ -130,14 +140,19 @@ func (srv *Function) GetFunction(functionId string) (*FunctionObject, error) {
 }
 
 func (srv *Function) ListDeployments(functionId, Search string, Queries []string) (*DeploymentListResponse, error) {
-	r := strings.NewReplacer("{functionId}", functionId)
+	return srv.ListDeploymentsContext(context.Background(), functionId, Search, Queries)
+}
+
+// ListDeploymentsContext is like ListDeployments but cancels the request when ctx is done.
+func (srv *Function) ListDeploymentsContext(ctx context.Context, functionId, Search string, Queries []string) (*DeploymentListResponse, error) {
+	r := pathReplacer("{functionId}", functionId)
 	path := r.Replace("/functions/{functionId}/deployments")
 	params := map[string]interface{}{
 		"search":  Search,
 		"queries": Queries,
 	}
 
-	resp, err := srv.Client.CallAPI("GET", path, srv.Client.headers, params)
+	resp, err := srv.Client.CallAPIContext(ctx, "GET", path, srv.Client.headers, params)
 	if err != nil {
 		return nil, err
 	}
@@ -149,11 +164,16 @@ func (srv *Function) ListDeployments(functionId, Search string, Queries []string
 }
 
 func (srv *Function) GetDeployment(functionId, deploymentId string) (*DeploymentObject, error) {
-	r := strings.NewReplacer("{functionId}", functionId, "{deploymentId}", deploymentId)
+	return srv.GetDeploymentContext(context.Background(), functionId, deploymentId)
+}
+
+// GetDeploymentContext is like GetDeployment but cancels the request when ctx is done.
+func (srv *Function) GetDeploymentContext(ctx context.Context, functionId, deploymentId string) (*DeploymentObject, error) {
+	r := pathReplacer("{functionId}", functionId, "{deploymentId}", deploymentId)
 	path := r.Replace("/functions/{functionId}/deployments/{deploymentId}")
 	params := map[string]interface{}{}
 
-	resp, err := srv.Client.CallAPI("GET", path, srv.Client.headers, params)
+	resp, err := srv.Client.CallAPIContext(ctx, "GET", path, srv.Client.headers, params)
 	if err != nil {
 		return nil, err
 	}
@@ -165,14 +185,19 @@ func (srv *Function) GetDeployment(functionId, deploymentId string) (*Deployment
 }
 
 func (srv *Function) ListExecutions(functionId, Search string, Queries []string) (*ExecutionListResponse, error) {
-	r := strings.NewReplacer("{functionId}", functionId)
+	return srv.ListExecutionsContext(context.Background(), functionId, Search, Queries)
+}
+
+// ListExecutionsContext is like ListExecutions but cancels the request when ctx is done.
+func (srv *Function) ListExecutionsContext(ctx context.Context, functionId, Search string, Queries []string) (*ExecutionListResponse, error) {
+	r := pathReplacer("{functionId}", functionId)
 	path := r.Replace("/functions/{functionId}/executions")
 	params := map[string]interface{}{
 		"search":  Search,
 		"queries": Queries,
 	}
 
-	resp, err := srv.Client.CallAPI("GET", path, srv.Client.headers, params)
+	resp, err := srv.Client.CallAPIContext(ctx, "GET", path, srv.Client.headers, params)
 	if err != nil {
 		return nil, err
 	}
@@ -184,11 +209,16 @@ func (srv *Function) ListExecutions(functionId, Search string, Queries []string)
 }
 
 func (srv *Function) GetExecution(functionId, executionId string) (*ExecutionObject, error) {
-	r := strings.NewReplacer("{functionId}", functionId, "{executionId}", executionId)
+	return srv.GetExecutionContext(context.Background(), functionId, executionId)
+}
+
+// GetExecutionContext is like GetExecution but cancels the request when ctx is done.
+func (srv *Function) GetExecutionContext(ctx context.Context, functionId, executionId string) (*ExecutionObject, error) {
+	r := pathReplacer("{functionId}", functionId, "{executionId}", executionId)
 	path := r.Replace("/functions/{functionId}/executions/{executionId}")
 	params := map[string]interface{}{}
 
-	resp, err := srv.Client.CallAPI("GET", path, srv.Client.headers, params)
+	resp, err := srv.Client.CallAPIContext(ctx, "GET", path, srv.Client.headers, params)
 	if err != nil {
 		return nil, err
 	}
@@ -200,13 +230,19 @@ func (srv *Function) GetExecution(functionId, executionId string) (*ExecutionObj
 }
 
 func (srv *Function) ListVariables(functionId, Search string, Queries []string) (*VariableListResponse, error) {
-	path := "/functions/{functionId}/variables"
+	return srv.ListVariablesContext(context.Background(), functionId, Search, Queries)
+}
+
+// ListVariablesContext is like ListVariables but cancels the request when ctx is done.
+func (srv *Function) ListVariablesContext(ctx context.Context, functionId, Search string, Queries []string) (*VariableListResponse, error) {
+	r := pathReplacer("{functionId}", functionId)
+	path := r.Replace("/functions/{functionId}/variables")
 	params := map[string]interface{}{
 		"search":  Search,
 		"queries": Queries,
 	}
 
-	resp, err := srv.Client.CallAPI("GET", path, srv.Client.headers, params)
+	resp, err := srv.Client.CallAPIContext(ctx, "GET", path, srv.Client.headers, params)
 	if err != nil {
 		return nil, err
 	}
@@ -218,11 +254,67 @@ func (srv *Function) ListVariables(functionId, Search string, Queries []string)
 }
 
 func (srv *Function) GetVariable(functionId, variableId string) (*Variable, error) {
-	r := strings.NewReplacer("{functionId}", functionId, "{variableId}", variableId)
+	return srv.GetVariableContext(context.Background(), functionId, variableId)
+}
+
+// GetVariableContext is like GetVariable but cancels the request when ctx is done.
+func (srv *Function) GetVariableContext(ctx context.Context, functionId, variableId string) (*Variable, error) {
+	r := pathReplacer("{functionId}", functionId, "{variableId}", variableId)
 	path := r.Replace("/functions/{functionId}/variables/{variableId}")
 	params := map[string]interface{}{}
 
-	resp, err := srv.Client.CallAPI("GET", path, srv.Client.headers, params)
+	resp, err := srv.Client.CallAPIContext(ctx, "GET", path, srv.Client.headers, params)
+	if err != nil {
+		return nil, err
+	}
+	var result Variable
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// CreateVariable create a new function variable. Variables are accessible in
+// the function at runtime as environment variables.
+func (srv *Function) CreateVariable(functionId, key, value string) (*Variable, error) {
+	return srv.CreateVariableContext(context.Background(), functionId, key, value)
+}
+
+// CreateVariableContext is like CreateVariable but cancels the request when ctx is done.
+func (srv *Function) CreateVariableContext(ctx context.Context, functionId, key, value string) (*Variable, error) {
+	r := pathReplacer("{functionId}", functionId)
+	path := r.Replace("/functions/{functionId}/variables")
+	params := map[string]interface{}{
+		"key":   key,
+		"value": value,
+	}
+
+	resp, err := srv.Client.CallAPIContext(ctx, "POST", path, srv.Client.headers, params)
+	if err != nil {
+		return nil, err
+	}
+	var result Variable
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// UpdateVariable update a function variable by its unique ID.
+func (srv *Function) UpdateVariable(functionId, variableId, key, value string) (*Variable, error) {
+	return srv.UpdateVariableContext(context.Background(), functionId, variableId, key, value)
+}
+
+// UpdateVariableContext is like UpdateVariable but cancels the request when ctx is done.
+func (srv *Function) UpdateVariableContext(ctx context.Context, functionId, variableId, key, value string) (*Variable, error) {
+	r := pathReplacer("{functionId}", functionId, "{variableId}", variableId)
+	path := r.Replace("/functions/{functionId}/variables/{variableId}")
+	params := map[string]interface{}{
+		"key":   key,
+		"value": value,
+	}
+
+	resp, err := srv.Client.CallAPIContext(ctx, "PUT", path, srv.Client.headers, params)
 	if err != nil {
 		return nil, err
 	}
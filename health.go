@@ -1,6 +1,7 @@
 package appwrite
 
 import (
+	"context"
 	"encoding/json"
 )
 
@@ -20,8 +21,13 @@ type HealthTime struct {
 }
 
 func (srv *Client) Health() (*HealthStatus, error) {
+	return srv.HealthContext(context.Background())
+}
+
+// HealthContext is like Health but cancels the request when ctx is done.
+func (srv *Client) HealthContext(ctx context.Context) (*HealthStatus, error) {
 	path := "/health"
-	resp, err := srv.CallAPI("GET", path, srv.headers, nil)
+	resp, err := srv.CallAPIContext(ctx, "GET", path, srv.headers, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -33,8 +39,13 @@ func (srv *Client) Health() (*HealthStatus, error) {
 }
 
 func (srv *Client) DBHealth() (*HealthStatus, error) {
+	return srv.DBHealthContext(context.Background())
+}
+
+// DBHealthContext is like DBHealth but cancels the request when ctx is done.
+func (srv *Client) DBHealthContext(ctx context.Context) (*HealthStatus, error) {
 	path := "/health/db"
-	resp, err := srv.CallAPI("GET", path, srv.headers, nil)
+	resp, err := srv.CallAPIContext(ctx, "GET", path, srv.headers, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -46,8 +57,13 @@ func (srv *Client) DBHealth() (*HealthStatus, error) {
 }
 
 func (srv *Client) CacheHealth() (*HealthStatus, error) {
+	return srv.CacheHealthContext(context.Background())
+}
+
+// CacheHealthContext is like CacheHealth but cancels the request when ctx is done.
+func (srv *Client) CacheHealthContext(ctx context.Context) (*HealthStatus, error) {
 	path := "/health/cache"
-	resp, err := srv.CallAPI("GET", path, srv.headers, nil)
+	resp, err := srv.CallAPIContext(ctx, "GET", path, srv.headers, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -59,8 +75,13 @@ func (srv *Client) CacheHealth() (*HealthStatus, error) {
 }
 
 func (srv *Client) LocalStorageHealth() (*HealthStatus, error) {
+	return srv.LocalStorageHealthContext(context.Background())
+}
+
+// LocalStorageHealthContext is like LocalStorageHealth but cancels the request when ctx is done.
+func (srv *Client) LocalStorageHealthContext(ctx context.Context) (*HealthStatus, error) {
 	path := "/health/storage/local"
-	resp, err := srv.CallAPI("GET", path, srv.headers, nil)
+	resp, err := srv.CallAPIContext(ctx, "GET", path, srv.headers, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -72,8 +93,13 @@ func (srv *Client) LocalStorageHealth() (*HealthStatus, error) {
 }
 
 func (srv *Client) FunctionsQueue() (*HealthQueue, error) {
+	return srv.FunctionsQueueContext(context.Background())
+}
+
+// FunctionsQueueContext is like FunctionsQueue but cancels the request when ctx is done.
+func (srv *Client) FunctionsQueueContext(ctx context.Context) (*HealthQueue, error) {
 	path := "/health/queue/functions"
-	resp, err := srv.CallAPI("GET", path, srv.headers, nil)
+	resp, err := srv.CallAPIContext(ctx, "GET", path, srv.headers, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -85,8 +111,13 @@ func (srv *Client) FunctionsQueue() (*HealthQueue, error) {
 }
 
 func (srv *Client) LogsQueue() (*HealthQueue, error) {
+	return srv.LogsQueueContext(context.Background())
+}
+
+// LogsQueueContext is like LogsQueue but cancels the request when ctx is done.
+func (srv *Client) LogsQueueContext(ctx context.Context) (*HealthQueue, error) {
 	path := "/health/queue/logs"
-	resp, err := srv.CallAPI("GET", path, srv.headers, nil)
+	resp, err := srv.CallAPIContext(ctx, "GET", path, srv.headers, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -98,8 +129,13 @@ func (srv *Client) LogsQueue() (*HealthQueue, error) {
 }
 
 func (srv *Client) WebHooksQueue() (*HealthQueue, error) {
+	return srv.WebHooksQueueContext(context.Background())
+}
+
+// WebHooksQueueContext is like WebHooksQueue but cancels the request when ctx is done.
+func (srv *Client) WebHooksQueueContext(ctx context.Context) (*HealthQueue, error) {
 	path := "/health/queue/webhooks"
-	resp, err := srv.CallAPI("GET", path, srv.headers, nil)
+	resp, err := srv.CallAPIContext(ctx, "GET", path, srv.headers, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -111,8 +147,13 @@ func (srv *Client) WebHooksQueue() (*HealthQueue, error) {
 }
 
 func (srv *Client) TimeHealth() (*HealthTime, error) {
+	return srv.TimeHealthContext(context.Background())
+}
+
+// TimeHealthContext is like TimeHealth but cancels the request when ctx is done.
+func (srv *Client) TimeHealthContext(ctx context.Context) (*HealthTime, error) {
 	path := "/health/time"
-	resp, err := srv.CallAPI("GET", path, srv.headers, nil)
+	resp, err := srv.CallAPIContext(ctx, "GET", path, srv.headers, nil)
 	if err != nil {
 		return nil, err
 	}
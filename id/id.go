@@ -0,0 +1,68 @@
+// Package id generates and validates the IDs of Appwrite resources.
+package id
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// MaxLength is the maximum length of a custom ID
+const MaxLength = 36
+
+// ErrInvalid is matched by every error returned for a malformed ID
+var ErrInvalid = errors.New("id: invalid ID")
+
+// Unique lets the server generate a unique ID
+func Unique() string {
+	return "unique()"
+}
+
+// New generates a unique ID locally. IDs are 20 characters long and sort in
+// the order they were generated in, as the first 13 characters encode the
+// current time in microseconds.
+func New() string {
+	now := time.Now()
+	random := make([]byte, 4)
+	if _, err := rand.Read(random); err != nil {
+		panic(err)
+	}
+	return fmt.Sprintf("%08x%05x", now.Unix(), now.Nanosecond()/1000) + hex.EncodeToString(random)[:7]
+}
+
+// Custom validates a custom ID, which must be at most 36 characters among
+// a-z, A-Z, 0-9, period, hyphen and underscore, and can't start with a
+// special character
+func Custom(s string) (string, error) {
+	if err := Validate(s); err != nil {
+		return "", err
+	}
+	return s, nil
+}
+
+// Validate reports whether s is either unique() or a valid custom ID
+func Validate(s string) error {
+	if s == Unique() {
+		return nil
+	}
+	if s == "" {
+		return fmt.Errorf("%w: empty", ErrInvalid)
+	}
+	if len(s) > MaxLength {
+		return fmt.Errorf("%w: %q is longer than %d characters", ErrInvalid, s, MaxLength)
+	}
+	for i, c := range s {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case c == '.' || c == '-' || c == '_':
+			if i == 0 {
+				return fmt.Errorf("%w: %q can't start with a special character", ErrInvalid, s)
+			}
+		default:
+			return fmt.Errorf("%w: %q contains invalid character %q", ErrInvalid, s, c)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package appwrite
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// IndexType is the type of a collection index
+type IndexType string
+
+// Index types
+const (
+	IndexKey      IndexType = "key"
+	IndexUnique   IndexType = "unique"
+	IndexFulltext IndexType = "fulltext"
+)
+
+// IndexOrder is the sort order of an indexed attribute
+type IndexOrder string
+
+// Index orders
+const (
+	OrderAsc  IndexOrder = "ASC"
+	OrderDesc IndexOrder = "DESC"
+)
+
+// ListIndexes get a list of all the indexes of a collection.
+func (srv *Database) ListIndexes(databaseId, collectionId string, Queries []string) (*IndexList, error) {
+	return srv.ListIndexesContext(context.Background(), databaseId, collectionId, Queries)
+}
+
+// ListIndexesContext is like ListIndexes but cancels the request when ctx is done.
+func (srv *Database) ListIndexesContext(ctx context.Context, databaseId, collectionId string, Queries []string) (*IndexList, error) {
+	r := pathReplacer("{databaseId}", databaseId, "{collectionId}", collectionId)
+	path := r.Replace("/databases/{databaseId}/collections/{collectionId}/indexes")
+
+	params := map[string]interface{}{
+		"queries": Queries,
+	}
+
+	resp, err := srv.Client.CallAPIContext(ctx, "GET", path, srv.Client.headers, params)
+	if err != nil {
+		return nil, err
+	}
+	var result IndexList
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// CreateIndex create an index on the given attributes of a collection. Orders
+// holds the sort order of each attribute and may be left empty.
+func (srv *Database) CreateIndex(databaseId, collectionId, key string, Type IndexType, attributes []string, orders []IndexOrder) (*Index, error) {
+	return srv.CreateIndexContext(context.Background(), databaseId, collectionId, key, Type, attributes, orders)
+}
+
+// CreateIndexContext is like CreateIndex but cancels the request when ctx is done.
+func (srv *Database) CreateIndexContext(ctx context.Context, databaseId, collectionId, key string, Type IndexType, attributes []string, orders []IndexOrder) (*Index, error) {
+	r := pathReplacer("{databaseId}", databaseId, "{collectionId}", collectionId)
+	path := r.Replace("/databases/{databaseId}/collections/{collectionId}/indexes")
+
+	params := map[string]interface{}{
+		"key":        key,
+		"type":       Type,
+		"attributes": attributes,
+	}
+	if len(orders) > 0 {
+		params["orders"] = orders
+	}
+
+	resp, err := srv.Client.CallAPIContext(ctx, "POST", path, srv.Client.headers, params)
+	if err != nil {
+		return nil, err
+	}
+	var result Index
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetIndex get an index of a collection by its key.
+func (srv *Database) GetIndex(databaseId, collectionId, key string) (*Index, error) {
+	return srv.GetIndexContext(context.Background(), databaseId, collectionId, key)
+}
+
+// GetIndexContext is like GetIndex but cancels the request when ctx is done.
+func (srv *Database) GetIndexContext(ctx context.Context, databaseId, collectionId, key string) (*Index, error) {
+	r := pathReplacer("{databaseId}", databaseId, "{collectionId}", collectionId, "{key}", key)
+	path := r.Replace("/databases/{databaseId}/collections/{collectionId}/indexes/{key}")
+
+	params := map[string]interface{}{}
+
+	resp, err := srv.Client.CallAPIContext(ctx, "GET", path, srv.Client.headers, params)
+	if err != nil {
+		return nil, err
+	}
+	var result Index
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DeleteIndex delete an index of a collection by its key.
+func (srv *Database) DeleteIndex(databaseId, collectionId, key string) error {
+	return srv.DeleteIndexContext(context.Background(), databaseId, collectionId, key)
+}
+
+// DeleteIndexContext is like DeleteIndex but cancels the request when ctx is done.
+func (srv *Database) DeleteIndexContext(ctx context.Context, databaseId, collectionId, key string) error {
+	r := pathReplacer("{databaseId}", databaseId, "{collectionId}", collectionId, "{key}", key)
+	path := r.Replace("/databases/{databaseId}/collections/{collectionId}/indexes/{key}")
+
+	params := map[string]interface{}{}
+
+	_, err := srv.Client.CallAPIContext(ctx, "DELETE", path, srv.Client.headers, params)
+	return err
+}
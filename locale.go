@@ -1,6 +1,7 @@
 package appwrite
 
 import (
+	"context"
 )
 
 // Locale service
@@ -8,81 +9,105 @@ type Locale struct {
 	Client Client
 }
 
-func NewLocale(clt Client) Locale {  
-    service := Locale{
+func NewLocale(clt Client) Locale {
+	service := Locale{
 		Client: clt,
 	}
 
-    return service
+	return service
 }
 
 // Get get the current user location based on IP. Returns an object with user
 // country code, country name, continent name, continent code, ip address and
 // suggested currency. You can use the locale header to get the data in a
 // supported language.
-// 
+//
 // ([IP Geolocation by DB-IP](https://db-ip.com))
 func (srv *Locale) Get() (map[string]interface{}, error) {
+	return srv.GetContext(context.Background())
+}
+
+// GetContext is like Get but cancels the request when ctx is done.
+func (srv *Locale) GetContext(ctx context.Context) (map[string]interface{}, error) {
 	path := "/locale"
 
-	params := map[string]interface{}{
-	}
+	params := map[string]interface{}{}
 
-	return srv.Client.Call("GET", path, nil, params)
+	return srv.Client.CallContext(ctx, "GET", path, nil, params)
 }
 
 // GetContinents list of all continents. You can use the locale header to get
 // the data in a supported language.
 func (srv *Locale) GetContinents() (map[string]interface{}, error) {
+	return srv.GetContinentsContext(context.Background())
+}
+
+// GetContinentsContext is like GetContinents but cancels the request when ctx is done.
+func (srv *Locale) GetContinentsContext(ctx context.Context) (map[string]interface{}, error) {
 	path := "/locale/continents"
 
-	params := map[string]interface{}{
-	}
+	params := map[string]interface{}{}
 
-	return srv.Client.Call("GET", path, nil, params)
+	return srv.Client.CallContext(ctx, "GET", path, nil, params)
 }
 
 // GetCountries list of all countries. You can use the locale header to get
 // the data in a supported language.
 func (srv *Locale) GetCountries() (map[string]interface{}, error) {
+	return srv.GetCountriesContext(context.Background())
+}
+
+// GetCountriesContext is like GetCountries but cancels the request when ctx is done.
+func (srv *Locale) GetCountriesContext(ctx context.Context) (map[string]interface{}, error) {
 	path := "/locale/countries"
 
-	params := map[string]interface{}{
-	}
+	params := map[string]interface{}{}
 
-	return srv.Client.Call("GET", path, nil, params)
+	return srv.Client.CallContext(ctx, "GET", path, nil, params)
 }
 
 // GetCountriesEU list of all countries that are currently members of the EU.
 // You can use the locale header to get the data in a supported language.
 func (srv *Locale) GetCountriesEU() (map[string]interface{}, error) {
+	return srv.GetCountriesEUContext(context.Background())
+}
+
+// GetCountriesEUContext is like GetCountriesEU but cancels the request when ctx is done.
+func (srv *Locale) GetCountriesEUContext(ctx context.Context) (map[string]interface{}, error) {
 	path := "/locale/countries/eu"
 
-	params := map[string]interface{}{
-	}
+	params := map[string]interface{}{}
 
-	return srv.Client.Call("GET", path, nil, params)
+	return srv.Client.CallContext(ctx, "GET", path, nil, params)
 }
 
 // GetCountriesPhones list of all countries phone codes. You can use the
 // locale header to get the data in a supported language.
 func (srv *Locale) GetCountriesPhones() (map[string]interface{}, error) {
+	return srv.GetCountriesPhonesContext(context.Background())
+}
+
+// GetCountriesPhonesContext is like GetCountriesPhones but cancels the request when ctx is done.
+func (srv *Locale) GetCountriesPhonesContext(ctx context.Context) (map[string]interface{}, error) {
 	path := "/locale/countries/phones"
 
-	params := map[string]interface{}{
-	}
+	params := map[string]interface{}{}
 
-	return srv.Client.Call("GET", path, nil, params)
+	return srv.Client.CallContext(ctx, "GET", path, nil, params)
 }
 
 // GetCurrencies list of all currencies, including currency symol, name,
 // plural, and decimal digits for all major and minor currencies. You can use
 // the locale header to get the data in a supported language.
 func (srv *Locale) GetCurrencies() (map[string]interface{}, error) {
+	return srv.GetCurrenciesContext(context.Background())
+}
+
+// GetCurrenciesContext is like GetCurrencies but cancels the request when ctx is done.
+func (srv *Locale) GetCurrenciesContext(ctx context.Context) (map[string]interface{}, error) {
 	path := "/locale/currencies"
 
-	params := map[string]interface{}{
-	}
+	params := map[string]interface{}{}
 
-	return srv.Client.Call("GET", path, nil, params)
+	return srv.Client.CallContext(ctx, "GET", path, nil, params)
 }
@@ -0,0 +1,309 @@
+package appwrite
+
+import (
+	"context"
+	"encoding/json"
+	"iter"
+
+	"github.com/appwrite/sdk-for-go/query"
+)
+
+// DefaultPageSize is the number of items a Pager fetches per request when no
+// page size is given
+const DefaultPageSize = 25
+
+// Pager walks through every item of a list endpoint, one page at a time,
+// using cursor pagination
+//
+//	pager := db.DocumentsPager(ctx, databaseId, collectionId, nil, 100)
+//	for pager.Next() {
+//		doc := pager.Item()
+//	}
+//	if err := pager.Err(); err != nil {
+//		...
+//	}
+type Pager[T any] struct {
+	ctx      context.Context
+	queries  []string
+	pageSize int
+	fetch    func(ctx context.Context, queries []string) ([]T, error)
+	idOf     func(T) string
+
+	page   []T
+	item   T
+	cursor string
+	last   bool
+	err    error
+}
+
+// newPager creates a Pager fetching pages through fetch. Queries must not
+// hold limit, offset or cursor queries, which the Pager adds itself.
+func newPager[T any](ctx context.Context, queries []string, pageSize int, fetch func(context.Context, []string) ([]T, error), idOf func(T) string) *Pager[T] {
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	return &Pager[T]{
+		ctx:      ctx,
+		queries:  queries,
+		pageSize: pageSize,
+		fetch:    fetch,
+		idOf:     idOf,
+	}
+}
+
+// Next advances to the next item, fetching the next page when needed. It
+// returns false once every item was read, or when fetching a page failed or
+// the context was cancelled, in which case Err reports why.
+func (p *Pager[T]) Next() bool {
+	if p.err != nil {
+		return false
+	}
+	if len(p.page) == 0 {
+		if p.last {
+			return false
+		}
+		if p.err = p.ctx.Err(); p.err != nil {
+			return false
+		}
+
+		queries := append(append([]string{}, p.queries...), query.Limit(p.pageSize))
+		if p.cursor != "" {
+			queries = append(queries, query.CursorAfter(p.cursor))
+		}
+		p.page, p.err = p.fetch(p.ctx, queries)
+		if p.err != nil {
+			return false
+		}
+		p.last = len(p.page) < p.pageSize
+		if len(p.page) == 0 {
+			return false
+		}
+		p.cursor = p.idOf(p.page[len(p.page)-1])
+	}
+
+	p.item = p.page[0]
+	p.page = p.page[1:]
+	return true
+}
+
+// Item returns the current item
+func (p *Pager[T]) Item() T {
+	return p.item
+}
+
+// Err returns the error that stopped the Pager, if any
+func (p *Pager[T]) Err() error {
+	return p.err
+}
+
+// All returns an iterator over the remaining items. Iteration stops after
+// yielding the first error.
+func (p *Pager[T]) All() iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for p.Next() {
+			if !yield(p.Item(), nil) {
+				return
+			}
+		}
+		if p.err != nil {
+			var zero T
+			yield(zero, p.err)
+		}
+	}
+}
+
+// DocumentsPager page through the documents of a collection matching queries.
+func (srv *Database) DocumentsPager(ctx context.Context, databaseId, collectionId string, queries []string, pageSize int) *Pager[Document] {
+	return newPager(ctx, queries, pageSize, func(ctx context.Context, queries []string) ([]Document, error) {
+		result, err := srv.ListDocumentsContext(ctx, databaseId, collectionId, queries)
+		if err != nil {
+			return nil, err
+		}
+		return result.Documents, nil
+	}, func(doc Document) string { return doc.Id })
+}
+
+// AllDocuments iterate over the documents of a collection matching queries.
+func (srv *Database) AllDocuments(ctx context.Context, databaseId, collectionId string, queries []string, pageSize int) iter.Seq2[Document, error] {
+	return srv.DocumentsPager(ctx, databaseId, collectionId, queries, pageSize).All()
+}
+
+// CollectionsPager page through the collections of a database.
+func (srv *Database) CollectionsPager(ctx context.Context, databaseId string, queries []string, pageSize int) *Pager[Collection] {
+	return newPager(ctx, queries, pageSize, func(ctx context.Context, queries []string) ([]Collection, error) {
+		result, err := srv.ListCollectionsContext(ctx, databaseId, "", queries)
+		if err != nil {
+			return nil, err
+		}
+		return result.Collections, nil
+	}, func(collection Collection) string { return collection.Id })
+}
+
+// AllCollections iterate over the collections of a database.
+func (srv *Database) AllCollections(ctx context.Context, databaseId string, queries []string, pageSize int) iter.Seq2[Collection, error] {
+	return srv.CollectionsPager(ctx, databaseId, queries, pageSize).All()
+}
+
+// DatabasesPager page through the databases of the project.
+func (srv *Database) DatabasesPager(ctx context.Context, queries []string, pageSize int) *Pager[DatabaseObject] {
+	return newPager(ctx, queries, pageSize, func(ctx context.Context, queries []string) ([]DatabaseObject, error) {
+		result, err := srv.ListDatabasesContext(ctx, "", queries)
+		if err != nil {
+			return nil, err
+		}
+		return result.Databases, nil
+	}, func(database DatabaseObject) string { return database.Id })
+}
+
+// AllDatabases iterate over the databases of the project.
+func (srv *Database) AllDatabases(ctx context.Context, queries []string, pageSize int) iter.Seq2[DatabaseObject, error] {
+	return srv.DatabasesPager(ctx, queries, pageSize).All()
+}
+
+// BucketsPager page through the storage buckets of the project.
+func (srv *Storage) BucketsPager(ctx context.Context, queries []string, pageSize int) *Pager[Bucket] {
+	return newPager(ctx, queries, pageSize, func(ctx context.Context, queries []string) ([]Bucket, error) {
+		var result BucketListResponse
+		if err := listWithQueries(ctx, &srv.Client, "/storage/buckets", queries, &result); err != nil {
+			return nil, err
+		}
+		return result.Buckets, nil
+	}, func(bucket Bucket) string { return bucket.Id })
+}
+
+// AllBuckets iterate over the storage buckets of the project.
+func (srv *Storage) AllBuckets(ctx context.Context, queries []string, pageSize int) iter.Seq2[Bucket, error] {
+	return srv.BucketsPager(ctx, queries, pageSize).All()
+}
+
+// FilesPager page through the files of a bucket.
+func (srv *Storage) FilesPager(ctx context.Context, bucketId string, queries []string, pageSize int) *Pager[File] {
+	r := pathReplacer("{bucketId}", bucketId)
+	path := r.Replace("/storage/buckets/{bucketId}/files")
+
+	return newPager(ctx, queries, pageSize, func(ctx context.Context, queries []string) ([]File, error) {
+		var result FileListResponse
+		if err := listWithQueries(ctx, &srv.Client, path, queries, &result); err != nil {
+			return nil, err
+		}
+		return result.Files, nil
+	}, func(file File) string { return file.Id })
+}
+
+// AllFiles iterate over the files of a bucket.
+func (srv *Storage) AllFiles(ctx context.Context, bucketId string, queries []string, pageSize int) iter.Seq2[File, error] {
+	return srv.FilesPager(ctx, bucketId, queries, pageSize).All()
+}
+
+// Pager page through the users of the project.
+func (srv *Users) Pager(ctx context.Context, queries []string, pageSize int) *Pager[UserObject] {
+	return newPager(ctx, queries, pageSize, func(ctx context.Context, queries []string) ([]UserObject, error) {
+		var result struct {
+			Total int          `json:"total"`
+			Users []UserObject `json:"users"`
+		}
+		if err := listWithQueries(ctx, &srv.Client, "/users", queries, &result); err != nil {
+			return nil, err
+		}
+		return result.Users, nil
+	}, func(user UserObject) string { return user.Id })
+}
+
+// All iterate over the users of the project.
+func (srv *Users) All(ctx context.Context, queries []string, pageSize int) iter.Seq2[UserObject, error] {
+	return srv.Pager(ctx, queries, pageSize).All()
+}
+
+// Pager page through the teams of the project.
+func (srv *Teams) Pager(ctx context.Context, queries []string, pageSize int) *Pager[TeamObject] {
+	return newPager(ctx, queries, pageSize, func(ctx context.Context, queries []string) ([]TeamObject, error) {
+		var result TeamListResponse
+		if err := listWithQueries(ctx, &srv.Client, "/teams", queries, &result); err != nil {
+			return nil, err
+		}
+		return result.Teams, nil
+	}, func(team TeamObject) string { return team.Id })
+}
+
+// All iterate over the teams of the project.
+func (srv *Teams) All(ctx context.Context, queries []string, pageSize int) iter.Seq2[TeamObject, error] {
+	return srv.Pager(ctx, queries, pageSize).All()
+}
+
+// FunctionsPager page through the functions of the project.
+func (srv *Function) FunctionsPager(ctx context.Context, queries []string, pageSize int) *Pager[FunctionObject] {
+	return newPager(ctx, queries, pageSize, func(ctx context.Context, queries []string) ([]FunctionObject, error) {
+		result, err := srv.ListFunctionsContext(ctx, "", queries)
+		if err != nil {
+			return nil, err
+		}
+		return result.Functions, nil
+	}, func(function FunctionObject) string { return function.Id })
+}
+
+// AllFunctions iterate over the functions of the project.
+func (srv *Function) AllFunctions(ctx context.Context, queries []string, pageSize int) iter.Seq2[FunctionObject, error] {
+	return srv.FunctionsPager(ctx, queries, pageSize).All()
+}
+
+// DeploymentsPager page through the deployments of a function.
+func (srv *Function) DeploymentsPager(ctx context.Context, functionId string, queries []string, pageSize int) *Pager[DeploymentObject] {
+	return newPager(ctx, queries, pageSize, func(ctx context.Context, queries []string) ([]DeploymentObject, error) {
+		result, err := srv.ListDeploymentsContext(ctx, functionId, "", queries)
+		if err != nil {
+			return nil, err
+		}
+		return result.Deployments, nil
+	}, func(deployment DeploymentObject) string { return deployment.Id })
+}
+
+// AllDeployments iterate over the deployments of a function.
+func (srv *Function) AllDeployments(ctx context.Context, functionId string, queries []string, pageSize int) iter.Seq2[DeploymentObject, error] {
+	return srv.DeploymentsPager(ctx, functionId, queries, pageSize).All()
+}
+
+// ExecutionsPager page through the executions of a function.
+func (srv *Function) ExecutionsPager(ctx context.Context, functionId string, queries []string, pageSize int) *Pager[ExecutionObject] {
+	return newPager(ctx, queries, pageSize, func(ctx context.Context, queries []string) ([]ExecutionObject, error) {
+		result, err := srv.ListExecutionsContext(ctx, functionId, "", queries)
+		if err != nil {
+			return nil, err
+		}
+		return result.Executions, nil
+	}, func(execution ExecutionObject) string { return execution.Id })
+}
+
+// AllExecutions iterate over the executions of a function.
+func (srv *Function) AllExecutions(ctx context.Context, functionId string, queries []string, pageSize int) iter.Seq2[ExecutionObject, error] {
+	return srv.ExecutionsPager(ctx, functionId, queries, pageSize).All()
+}
+
+// listWithQueries lists path with the given queries and decodes the response
+// into result, for the list endpoints whose methods predate queries
+func listWithQueries(ctx context.Context, clt *Client, path string, queries []string, result interface{}) error {
+	params := map[string]interface{}{
+		"queries": queries,
+	}
+
+	resp, err := clt.CallAPIContext(ctx, "GET", path, clt.headers, params)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(resp, result)
+}
+
+// VariablesPager page through the variables of a function.
+func (srv *Function) VariablesPager(ctx context.Context, functionId string, queries []string, pageSize int) *Pager[Variable] {
+	return newPager(ctx, queries, pageSize, func(ctx context.Context, queries []string) ([]Variable, error) {
+		result, err := srv.ListVariablesContext(ctx, functionId, "", queries)
+		if err != nil {
+			return nil, err
+		}
+		return result.Variables, nil
+	}, func(variable Variable) string { return variable.Id })
+}
+
+// AllVariables iterate over the variables of a function.
+func (srv *Function) AllVariables(ctx context.Context, functionId string, queries []string, pageSize int) iter.Seq2[Variable, error] {
+	return srv.VariablesPager(ctx, functionId, queries, pageSize).All()
+}
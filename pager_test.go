@@ -0,0 +1,214 @@
+package appwrite
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestAllVariablesPages(t *testing.T) {
+	variables := []Variable{{Id: "v1", Key: "A"}, {Id: "v2", Key: "B"}, {Id: "v3", Key: "C"}}
+	var queries []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := strings.Join(r.URL.Query()["queries[]"], " ")
+		queries = append(queries, query)
+		page := variables[:2]
+		if strings.Contains(query, `cursorAfter("v2")`) {
+			page = variables[2:]
+		}
+		json.NewEncoder(w).Encode(VariableListResponse{Total: len(variables), Variables: page})
+	}))
+	defer srv.Close()
+
+	client := NewClient()
+	client.SetEndpoint(srv.URL)
+	functions := NewFunctions(client)
+
+	var keys []string
+	for variable, err := range functions.AllVariables(context.Background(), "f1", nil, 2) {
+		if err != nil {
+			t.Fatalf("AllVariables: %v", err)
+		}
+		keys = append(keys, variable.Key)
+	}
+	if want := []string{"A", "B", "C"}; !reflect.DeepEqual(keys, want) {
+		t.Errorf("keys = %v, want %v", keys, want)
+	}
+	if want := []string{"limit(2)", `limit(2) cursorAfter("v2")`}; fmt.Sprint(queries) != fmt.Sprint(want) {
+		t.Errorf("queries = %q, want %q", queries, want)
+	}
+}
+
+// documentsServer serves count documents with the ids d1 to dN through cursor
+// pagination, failing with a 500 once failAt requests were made when failAt
+// isn't zero
+type documentsServer struct {
+	count   int
+	failAt  int
+	queries []string
+}
+
+func (s *documentsServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	queries := r.URL.Query()["queries[]"]
+	s.queries = append(s.queries, strings.Join(queries, " "))
+	if len(s.queries) == s.failAt {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprint(w, `{"message":"Server Error","code":500,"type":"general_unknown"}`)
+		return
+	}
+
+	start, limit := 0, DefaultPageSize
+	for _, q := range queries {
+		fmt.Sscanf(q, "limit(%d)", &limit)
+		fmt.Sscanf(q, `cursorAfter("d%d")`, &start)
+	}
+	var docs []string
+	for i := start + 1; i <= s.count && len(docs) < limit; i++ {
+		docs = append(docs, fmt.Sprintf(`{"$id":"d%d"}`, i))
+	}
+	fmt.Fprintf(w, `{"total":%d,"documents":[%s]}`, s.count, strings.Join(docs, ","))
+}
+
+func newDocumentsServer(t *testing.T, s *documentsServer) *Database {
+	t.Helper()
+	srv := httptest.NewServer(s)
+	t.Cleanup(srv.Close)
+
+	client := NewClient()
+	client.SetEndpoint(srv.URL)
+	db := NewDatabase(client)
+	return &db
+}
+
+func TestAllDocumentsPages(t *testing.T) {
+	tests := []struct {
+		name    string
+		count   int
+		queries []string
+	}{
+		{"empty", 0, []string{`equal("a",["b"]) limit(2)`}},
+		{"partial last page", 5, []string{
+			`equal("a",["b"]) limit(2)`,
+			`equal("a",["b"]) limit(2) cursorAfter("d2")`,
+			`equal("a",["b"]) limit(2) cursorAfter("d4")`,
+		}},
+		{"full last page", 4, []string{
+			`equal("a",["b"]) limit(2)`,
+			`equal("a",["b"]) limit(2) cursorAfter("d2")`,
+			`equal("a",["b"]) limit(2) cursorAfter("d4")`,
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &documentsServer{count: tt.count}
+			db := newDocumentsServer(t, s)
+
+			var ids []string
+			for doc, err := range db.AllDocuments(context.Background(), "main", "posts", []string{`equal("a",["b"])`}, 2) {
+				if err != nil {
+					t.Fatalf("AllDocuments: %v", err)
+				}
+				ids = append(ids, doc.Id)
+			}
+			var want []string
+			for i := 1; i <= tt.count; i++ {
+				want = append(want, fmt.Sprintf("d%d", i))
+			}
+			if !reflect.DeepEqual(ids, want) {
+				t.Errorf("ids = %v, want %v", ids, want)
+			}
+			if !reflect.DeepEqual(s.queries, tt.queries) {
+				t.Errorf("queries = %q, want %q", s.queries, tt.queries)
+			}
+		})
+	}
+}
+
+func TestAllDocumentsBreak(t *testing.T) {
+	s := &documentsServer{count: 10}
+	db := newDocumentsServer(t, s)
+
+	var ids []string
+	for doc, err := range db.AllDocuments(context.Background(), "main", "posts", nil, 2) {
+		if err != nil {
+			t.Fatalf("AllDocuments: %v", err)
+		}
+		ids = append(ids, doc.Id)
+		if len(ids) == 3 {
+			break
+		}
+	}
+	if want := []string{"d1", "d2", "d3"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("ids = %v, want %v", ids, want)
+	}
+	if len(s.queries) != 2 {
+		t.Errorf("%d pages were fetched, want 2", len(s.queries))
+	}
+}
+
+func TestAllDocumentsError(t *testing.T) {
+	s := &documentsServer{count: 10, failAt: 2}
+	db := newDocumentsServer(t, s)
+
+	var ids []string
+	var errs []error
+	for doc, err := range db.AllDocuments(context.Background(), "main", "posts", nil, 2) {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		ids = append(ids, doc.Id)
+	}
+	if want := []string{"d1", "d2"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("ids = %v, want %v", ids, want)
+	}
+	var appwriteErr *AppwriteError
+	if len(errs) != 1 || !errors.As(errs[0], &appwriteErr) || appwriteErr.Code != http.StatusInternalServerError {
+		t.Fatalf("errors = %v, want a single 500 error", errs)
+	}
+	if len(s.queries) != 2 {
+		t.Errorf("%d pages were fetched, want 2", len(s.queries))
+	}
+
+	// The Pager keeps reporting the error instead of fetching again
+	pager := db.DocumentsPager(context.Background(), "main", "posts", nil, 2)
+	s.queries, s.failAt = nil, 1
+	if pager.Next() || pager.Next() {
+		t.Error("Next succeeded after a failed page")
+	}
+	if !errors.As(pager.Err(), &appwriteErr) || len(s.queries) != 1 {
+		t.Errorf("Err = %v after %d pages, want a 500 error after 1", pager.Err(), len(s.queries))
+	}
+}
+
+func TestAllDocumentsCancel(t *testing.T) {
+	s := &documentsServer{count: 10}
+	db := newDocumentsServer(t, s)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var ids []string
+	var errs []error
+	for doc, err := range db.AllDocuments(ctx, "main", "posts", nil, 2) {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		ids = append(ids, doc.Id)
+		cancel()
+	}
+	if want := []string{"d1", "d2"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("ids = %v, want the first page %v", ids, want)
+	}
+	if len(errs) != 1 || !errors.Is(errs[0], context.Canceled) {
+		t.Errorf("errors = %v, want context.Canceled", errs)
+	}
+}
@@ -0,0 +1,102 @@
+// Package permission builds and parses the permission strings granted on
+// collections, documents, buckets and files. Roles are built with the role
+// package.
+package permission
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/appwrite/sdk-for-go/role"
+)
+
+// Permission actions
+const (
+	ActionRead   = "read"
+	ActionWrite  = "write"
+	ActionCreate = "create"
+	ActionUpdate = "update"
+	ActionDelete = "delete"
+)
+
+// Permission is the structured form of a permission string such as
+// read("team:abc/owner")
+type Permission struct {
+	Action string
+	Role   role.Role
+}
+
+// Read grants the role r the right to read the resource
+func Read(r string) string {
+	return format(ActionRead, r)
+}
+
+// Write grants the role r the right to create, update and delete the resource
+func Write(r string) string {
+	return format(ActionWrite, r)
+}
+
+// Create grants the role r the right to create resources
+func Create(r string) string {
+	return format(ActionCreate, r)
+}
+
+// Update grants the role r the right to update the resource
+func Update(r string) string {
+	return format(ActionUpdate, r)
+}
+
+// Delete grants the role r the right to delete the resource
+func Delete(r string) string {
+	return format(ActionDelete, r)
+}
+
+// String formats the permission back into its string form
+func (p Permission) String() string {
+	return format(p.Action, p.Role.String())
+}
+
+// Parse parses a permission string such as read("team:abc/owner")
+func Parse(s string) (Permission, error) {
+	open := strings.IndexByte(s, '(')
+	if open < 0 || !strings.HasSuffix(s, ")") {
+		return Permission{}, fmt.Errorf("permission: malformed permission %q", s)
+	}
+
+	action := s[:open]
+	switch action {
+	case ActionRead, ActionWrite, ActionCreate, ActionUpdate, ActionDelete:
+	default:
+		return Permission{}, fmt.Errorf("permission: unknown action %q in %q", action, s)
+	}
+
+	quoted := s[open+1 : len(s)-1]
+	value, err := strconv.Unquote(quoted)
+	if err != nil {
+		return Permission{}, fmt.Errorf("permission: malformed role %s in %q", quoted, s)
+	}
+	r, err := role.Parse(value)
+	if err != nil {
+		return Permission{}, err
+	}
+	return Permission{Action: action, Role: r}, nil
+}
+
+// ParseAll parses every permission string, such as the Permissions of a
+// collection or document
+func ParseAll(permissions []string) ([]Permission, error) {
+	result := make([]Permission, 0, len(permissions))
+	for _, s := range permissions {
+		p, err := Parse(s)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, p)
+	}
+	return result, nil
+}
+
+func format(action, r string) string {
+	return action + "(" + strconv.Quote(r) + ")"
+}
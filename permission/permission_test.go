@@ -0,0 +1,98 @@
+package permission
+
+import (
+	"testing"
+
+	"github.com/appwrite/sdk-for-go/role"
+)
+
+func TestBuilders(t *testing.T) {
+	tests := []struct {
+		name       string
+		permission string
+		want       string
+	}{
+		{"read any", Read(role.Any()), `read("any")`},
+		{"write user", Write(role.User("u1", "")), `write("user:u1")`},
+		{"create users", Create(role.Users("verified")), `create("users/verified")`},
+		{"update team", Update(role.Team("t1", "owner")), `update("team:t1/owner")`},
+		{"delete member", Delete(role.Member("m1")), `delete("member:m1")`},
+		{"read label", Read(role.Label("admin")), `read("label:admin")`},
+		{"read guests", Read(role.Guests()), `read("guests")`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.permission != tt.want {
+				t.Errorf("got %s, want %s", tt.permission, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Permission
+	}{
+		{`read("any")`, Permission{Action: ActionRead, Role: role.Role{Kind: role.KindAny}}},
+		{`write("user:u1")`, Permission{Action: ActionWrite, Role: role.Role{Kind: role.KindUser, ID: "u1"}}},
+		{`create("users/verified")`, Permission{Action: ActionCreate, Role: role.Role{Kind: role.KindUsers, Qualifier: "verified"}}},
+		{`update("team:t1/owner")`, Permission{Action: ActionUpdate, Role: role.Role{Kind: role.KindTeam, ID: "t1", Qualifier: "owner"}}},
+		{`delete("label:admin")`, Permission{Action: ActionDelete, Role: role.Role{Kind: role.KindLabel, ID: "admin"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := Parse(tt.in)
+			if err != nil {
+				t.Fatalf("Parse: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+			if got.String() != tt.in {
+				t.Errorf("String() = %s, want %s", got.String(), tt.in)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+	}{
+		{"empty", ``},
+		{"no parentheses", `read`},
+		{"unclosed", `read("any"`},
+		{"unquoted role", `read(any)`},
+		{"single quotes", `read('any')`},
+		{"unknown action", `execute("any")`},
+		{"empty action", `("any")`},
+		{"unknown role", `read("everyone")`},
+		{"role missing id", `read("team")`},
+		{"trailing text", `read("any") `},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if p, err := Parse(tt.in); err == nil {
+				t.Errorf("Parse(%q) = %+v, want an error", tt.in, p)
+			}
+		})
+	}
+}
+
+func TestParseAll(t *testing.T) {
+	got, err := ParseAll([]string{`read("any")`, `update("user:u1")`})
+	if err != nil {
+		t.Fatalf("ParseAll: %v", err)
+	}
+	if len(got) != 2 || got[0].String() != `read("any")` || got[1].String() != `update("user:u1")` {
+		t.Errorf("ParseAll = %+v", got)
+	}
+	if _, err := ParseAll([]string{`read("any")`, `read(any)`}); err == nil {
+		t.Error("ParseAll with a malformed permission succeeded")
+	}
+}
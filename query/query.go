@@ -0,0 +1,141 @@
+// Package query builds the query strings accepted by the Queries parameter
+// of the Appwrite list endpoints.
+package query
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Equal matches documents where attribute equals value. Value may be a slice
+// to match any of its elements.
+func Equal(attribute string, value interface{}) string {
+	return addQuery(attribute, "equal", value)
+}
+
+// NotEqual matches documents where attribute differs from value
+func NotEqual(attribute string, value interface{}) string {
+	return addQuery(attribute, "notEqual", value)
+}
+
+// LessThan matches documents where attribute is lower than value
+func LessThan(attribute string, value interface{}) string {
+	return addQuery(attribute, "lessThan", value)
+}
+
+// LessThanEqual matches documents where attribute is lower than or equal to
+// value
+func LessThanEqual(attribute string, value interface{}) string {
+	return addQuery(attribute, "lessThanEqual", value)
+}
+
+// GreaterThan matches documents where attribute is greater than value
+func GreaterThan(attribute string, value interface{}) string {
+	return addQuery(attribute, "greaterThan", value)
+}
+
+// GreaterThanEqual matches documents where attribute is greater than or equal
+// to value
+func GreaterThanEqual(attribute string, value interface{}) string {
+	return addQuery(attribute, "greaterThanEqual", value)
+}
+
+// Between matches documents where attribute is between start and end,
+// inclusive
+func Between(attribute string, start, end interface{}) string {
+	return "between(" + encode(attribute) + ", " + encode(start) + ", " + encode(end) + ")"
+}
+
+// Search matches documents where the fulltext indexed attribute contains
+// value
+func Search(attribute string, value string) string {
+	return addQuery(attribute, "search", value)
+}
+
+// IsNull matches documents where attribute is null
+func IsNull(attribute string) string {
+	return "isNull(" + encode(attribute) + ")"
+}
+
+// IsNotNull matches documents where attribute is not null
+func IsNotNull(attribute string) string {
+	return "isNotNull(" + encode(attribute) + ")"
+}
+
+// StartsWith matches documents where attribute starts with value
+func StartsWith(attribute string, value string) string {
+	return addQuery(attribute, "startsWith", value)
+}
+
+// EndsWith matches documents where attribute ends with value
+func EndsWith(attribute string, value string) string {
+	return addQuery(attribute, "endsWith", value)
+}
+
+// Select limits the returned attributes to the given ones
+func Select(attributes []string) string {
+	return "select(" + encodeValues(attributes) + ")"
+}
+
+// OrderAsc sorts results by attribute in ascending order
+func OrderAsc(attribute string) string {
+	return "orderAsc(" + encode(attribute) + ")"
+}
+
+// OrderDesc sorts results by attribute in descending order
+func OrderDesc(attribute string) string {
+	return "orderDesc(" + encode(attribute) + ")"
+}
+
+// Limit returns at most limit results
+func Limit(limit int) string {
+	return "limit(" + strconv.Itoa(limit) + ")"
+}
+
+// Offset skips the first offset results
+func Offset(offset int) string {
+	return "offset(" + strconv.Itoa(offset) + ")"
+}
+
+// CursorAfter returns the results following the document with the given ID
+func CursorAfter(documentId string) string {
+	return "cursorAfter(" + encode(documentId) + ")"
+}
+
+// CursorBefore returns the results preceding the document with the given ID
+func CursorBefore(documentId string) string {
+	return "cursorBefore(" + encode(documentId) + ")"
+}
+
+// addQuery formats a method comparing attribute against a list of values
+func addQuery(attribute, method string, value interface{}) string {
+	return method + "(" + encode(attribute) + ", " + encodeValues(value) + ")"
+}
+
+// encodeValues encodes value as a JSON array, wrapping single values
+func encodeValues(value interface{}) string {
+	v := reflect.ValueOf(value)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return "[" + encode(value) + "]"
+	}
+	values := make([]string, v.Len())
+	for i := range values {
+		values[i] = encode(v.Index(i).Interface())
+	}
+	return "[" + strings.Join(values, ",") + "]"
+}
+
+// encode encodes a single value as JSON, escaping quotes and backslashes in
+// strings
+func encode(value interface{}) string {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(value); err != nil {
+		return "null"
+	}
+	return strings.TrimSuffix(buf.String(), "\n")
+}
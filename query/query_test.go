@@ -0,0 +1,52 @@
+package query
+
+import "testing"
+
+func TestQueries(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{"equal string", Equal("title", "Iron Man"), `equal("title", ["Iron Man"])`},
+		{"equal slice", Equal("title", []string{"Iron Man", "Thor"}), `equal("title", ["Iron Man","Thor"])`},
+		{"equal int slice", Equal("year", []int{1999, 2008}), `equal("year", [1999,2008])`},
+		{"equal bool", Equal("published", true), `equal("published", [true])`},
+		{"equal float", Equal("rating", 4.5), `equal("rating", [4.5])`},
+		{"equal quotes", Equal("title", `say "hi"`), `equal("title", ["say \"hi\""])`},
+		{"equal backslash", Equal("path", `C:\dir`), `equal("path", ["C:\\dir"])`},
+		{"equal html", Equal("tag", "<b>&</b>"), `equal("tag", ["<b>&</b>"])`},
+		{"equal unicode", Equal("name", "Zoë"), `equal("name", ["Zoë"])`},
+		{"equal newline", Equal("text", "a\nb"), `equal("text", ["a\nb"])`},
+		{"equal null", Equal("title", nil), `equal("title", [null])`},
+		{"not equal", NotEqual("status", "draft"), `notEqual("status", ["draft"])`},
+		{"less than", LessThan("age", 18), `lessThan("age", [18])`},
+		{"less than equal", LessThanEqual("age", 18), `lessThanEqual("age", [18])`},
+		{"greater than", GreaterThan("score", 9.5), `greaterThan("score", [9.5])`},
+		{"greater than equal", GreaterThanEqual("score", 10), `greaterThanEqual("score", [10])`},
+		{"between", Between("age", 18, 30), `between("age", 18, 30)`},
+		{"between strings", Between("date", "2020-01-01", "2021-01-01"), `between("date", "2020-01-01", "2021-01-01")`},
+		{"search", Search("body", "hello world"), `search("body", ["hello world"])`},
+		{"is null", IsNull("deletedAt"), `isNull("deletedAt")`},
+		{"is not null", IsNotNull("deletedAt"), `isNotNull("deletedAt")`},
+		{"starts with", StartsWith("name", "Jo"), `startsWith("name", ["Jo"])`},
+		{"ends with", EndsWith("email", "@example.com"), `endsWith("email", ["@example.com"])`},
+		{"select", Select([]string{"name", "title"}), `select(["name","title"])`},
+		{"select none", Select(nil), `select([])`},
+		{"order asc", OrderAsc("title"), `orderAsc("title")`},
+		{"order desc", OrderDesc("title"), `orderDesc("title")`},
+		{"limit", Limit(25), `limit(25)`},
+		{"offset", Offset(50), `offset(50)`},
+		{"cursor after", CursorAfter("abc"), `cursorAfter("abc")`},
+		{"cursor before", CursorBefore("abc"), `cursorBefore("abc")`},
+		{"attribute escaping", Equal(`we"ird`, 1), `equal("we\"ird", [1])`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.query != tt.want {
+				t.Errorf("got  %s\nwant %s", tt.query, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package appwrite
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+)
+
+// AttributeTypeRelationship is the type of relationship attributes
+const AttributeTypeRelationship = "relationship"
+
+// Relationship types
+const (
+	RelationOneToOne   = "oneToOne"
+	RelationOneToMany  = "oneToMany"
+	RelationManyToOne  = "manyToOne"
+	RelationManyToMany = "manyToMany"
+)
+
+// What happens to related documents when a document is deleted
+const (
+	OnDeleteCascade  = "cascade"
+	OnDeleteRestrict = "restrict"
+	OnDeleteSetNull  = "setNull"
+)
+
+// CreateRelationshipAttribute create a relationship attribute linking the
+// collection to relatedCollectionId. When twoWay is set, the related
+// collection gets a twoWayKey attribute pointing back.
+func (srv *Database) CreateRelationshipAttribute(databaseId, collectionId, relatedCollectionId, Type string, twoWay bool, key, twoWayKey, onDelete string) (*Attribute, error) {
+	return srv.CreateRelationshipAttributeContext(context.Background(), databaseId, collectionId, relatedCollectionId, Type, twoWay, key, twoWayKey, onDelete)
+}
+
+// CreateRelationshipAttributeContext is like CreateRelationshipAttribute but cancels the request when ctx is done.
+func (srv *Database) CreateRelationshipAttributeContext(ctx context.Context, databaseId, collectionId, relatedCollectionId, Type string, twoWay bool, key, twoWayKey, onDelete string) (*Attribute, error) {
+	params := map[string]interface{}{
+		"relatedCollectionId": relatedCollectionId,
+		"type":                Type,
+		"twoWay":              twoWay,
+		"onDelete":            onDelete,
+	}
+	if key != "" {
+		params["key"] = key
+	}
+	if twoWayKey != "" {
+		params["twoWayKey"] = twoWayKey
+	}
+	return srv.createAttribute(ctx, databaseId, collectionId, "relationship", params)
+}
+
+// UpdateRelationshipAttribute update the on-delete behaviour of a
+// relationship attribute.
+func (srv *Database) UpdateRelationshipAttribute(databaseId, collectionId, key, onDelete string) (*Attribute, error) {
+	return srv.UpdateRelationshipAttributeContext(context.Background(), databaseId, collectionId, key, onDelete)
+}
+
+// UpdateRelationshipAttributeContext is like UpdateRelationshipAttribute but cancels the request when ctx is done.
+func (srv *Database) UpdateRelationshipAttributeContext(ctx context.Context, databaseId, collectionId, key, onDelete string) (*Attribute, error) {
+	r := pathReplacer("{databaseId}", databaseId, "{collectionId}", collectionId, "{key}", key)
+	path := r.Replace("/databases/{databaseId}/collections/{collectionId}/attributes/{key}/relationship")
+
+	params := map[string]interface{}{
+		"onDelete": onDelete,
+	}
+
+	resp, err := srv.Client.CallAPIContext(ctx, "PATCH", path, srv.Client.headers, params)
+	if err != nil {
+		return nil, err
+	}
+	var result Attribute
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// resolveRelated turns related documents nested in an attribute value into
+// Document values. A single related document becomes a Document and a list
+// of them a []Document; any other value is returned unchanged.
+func resolveRelated(val interface{}) interface{} {
+	switch v := val.(type) {
+	case map[string]interface{}:
+		if isDocumentMap(v) {
+			return documentFromMap(v)
+		}
+	case []interface{}:
+		if len(v) == 0 {
+			return v
+		}
+		docs := make([]Document, 0, len(v))
+		for _, item := range v {
+			m, ok := item.(map[string]interface{})
+			if !ok || !isDocumentMap(m) {
+				return v
+			}
+			docs = append(docs, documentFromMap(m))
+		}
+		return docs
+	}
+	return val
+}
+
+// isDocumentMap reports whether m holds a document rather than plain data
+func isDocumentMap(m map[string]interface{}) bool {
+	_, hasId := m["$id"].(string)
+	_, hasCollection := m["$collectionId"].(string)
+	return hasId && hasCollection
+}
+
+// documentFromMap builds a Document out of a decoded JSON object
+func documentFromMap(m map[string]interface{}) Document {
+	doc := Document{
+		Fields: make(map[string]interface{}),
+	}
+	doc.Id, _ = m["$id"].(string)
+	doc.CreatedAt, _ = m["$createdAt"].(string)
+	doc.UpdatedAt, _ = m["$updatedAt"].(string)
+	doc.CollectionId, _ = m["$collectionId"].(string)
+	doc.DatabaseId, _ = m["$databaseId"].(string)
+	if permissions, ok := m["$permissions"].([]interface{}); ok {
+		for _, permission := range permissions {
+			if str, ok := permission.(string); ok {
+				doc.Permissions = append(doc.Permissions, str)
+			}
+		}
+	}
+	for key, val := range m {
+		if !strings.HasPrefix(key, "$") {
+			doc.Fields[key] = resolveRelated(val)
+		}
+	}
+	return doc
+}
@@ -0,0 +1,186 @@
+package appwrite
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RetryPolicy configures how the Client retries failed requests
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one
+	MaxAttempts int
+	// BaseBackoff is the delay before the first retry, doubled on each attempt
+	BaseBackoff time.Duration
+	// MaxBackoff caps the delay between attempts, both the computed
+	// exponential one and the one requested by the server
+	MaxBackoff time.Duration
+	// Jitter is the fraction (0 to 1) of each delay that is randomised
+	Jitter float64
+	// RetryStatusCodes lists the HTTP status codes that trigger a retry
+	RetryStatusCodes []int
+	// IdempotentMethods lists the HTTP methods that are safe to retry
+	IdempotentMethods []string
+	// OnRetry is called before sleeping ahead of each retry
+	OnRetry func(RetryEvent)
+}
+
+// RetryEvent describes a retry about to happen
+type RetryEvent struct {
+	Method     string
+	Path       string
+	Attempt    int
+	Delay      time.Duration
+	StatusCode int
+	Err        error
+}
+
+// DefaultRetryPolicy returns a policy retrying idempotent requests on 429,
+// 502, 503 and 504 responses up to 4 times
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 4,
+		BaseBackoff: 500 * time.Millisecond,
+		MaxBackoff:  30 * time.Second,
+		Jitter:      0.2,
+		RetryStatusCodes: []int{
+			http.StatusTooManyRequests,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+		IdempotentMethods: []string{"GET", "HEAD", "OPTIONS", "PUT", "DELETE"},
+	}
+}
+
+// SetRetryPolicy sets the policy the Client uses to retry failed requests
+func (clt *Client) SetRetryPolicy(policy RetryPolicy) {
+	clt.retry = &policy
+}
+
+// shouldRetryMethod reports whether requests with the given method may be retried
+func (policy *RetryPolicy) shouldRetryMethod(method string) bool {
+	for _, m := range policy.IdempotentMethods {
+		if strings.EqualFold(m, method) {
+			return true
+		}
+	}
+	return false
+}
+
+// shouldRetryStatus reports whether the status code triggers a retry
+func (policy *RetryPolicy) shouldRetryStatus(status int) bool {
+	for _, code := range policy.RetryStatusCodes {
+		if code == status {
+			return true
+		}
+	}
+	return false
+}
+
+// backoff computes the delay before the given retry attempt, preferring the
+// delay requested by the server through Retry-After or X-RateLimit-Reset
+func (policy *RetryPolicy) backoff(attempt int, response *http.Response) time.Duration {
+	if response != nil {
+		if delay, ok := serverDelay(response); ok {
+			// Don't let a far-future reset block the caller
+			if policy.MaxBackoff > 0 && delay > policy.MaxBackoff {
+				delay = policy.MaxBackoff
+			}
+			return delay
+		}
+	}
+
+	delay := time.Duration(float64(policy.BaseBackoff) * math.Pow(2, float64(attempt-1)))
+	if policy.MaxBackoff > 0 && (delay > policy.MaxBackoff || delay <= 0) {
+		delay = policy.MaxBackoff
+	}
+	if policy.Jitter > 0 {
+		delay -= time.Duration(rand.Float64() * policy.Jitter * float64(delay))
+	}
+	return delay
+}
+
+// serverDelay reads the delay requested by the server from the response headers
+func serverDelay(response *http.Response) (time.Duration, bool) {
+	if value := response.Header.Get("Retry-After"); value != "" {
+		if seconds, err := strconv.Atoi(value); err == nil {
+			return time.Duration(seconds) * time.Second, true
+		}
+		if date, err := http.ParseTime(value); err == nil {
+			return positive(time.Until(date)), true
+		}
+	}
+	if response.StatusCode == http.StatusTooManyRequests {
+		if value := response.Header.Get("X-RateLimit-Reset"); value != "" {
+			if reset, err := strconv.ParseInt(value, 10, 64); err == nil {
+				return positive(time.Until(time.Unix(reset, 0))), true
+			}
+		}
+	}
+	return 0, false
+}
+
+func positive(d time.Duration) time.Duration {
+	if d < 0 {
+		return 0
+	}
+	return d
+}
+
+// do sends the request produced by newRequest, retrying according to the
+// Client retry policy. The body of the returned response must be closed by
+// the caller.
+func (clt *Client) do(ctx context.Context, newRequest func() (*http.Request, error)) (*http.Response, error) {
+	for attempt := 1; ; attempt++ {
+		req, err := newRequest()
+		if err != nil {
+			return nil, err
+		}
+
+		response, err := clt.client.Do(req)
+
+		policy := clt.retry
+		if policy == nil || attempt >= policy.MaxAttempts || !policy.shouldRetryMethod(req.Method) {
+			return response, err
+		}
+		if err == nil && !policy.shouldRetryStatus(response.StatusCode) {
+			return response, nil
+		}
+		if err != nil && ctx.Err() != nil {
+			return nil, err
+		}
+
+		delay := policy.backoff(attempt, response)
+		event := RetryEvent{
+			Method:  req.Method,
+			Path:    req.URL.Path,
+			Attempt: attempt,
+			Delay:   delay,
+			Err:     err,
+		}
+		if response != nil {
+			event.StatusCode = response.StatusCode
+			// Drain the body so the connection can be reused
+			io.Copy(ioutil.Discard, response.Body)
+			response.Body.Close()
+		}
+		if policy.OnRetry != nil {
+			policy.OnRetry(event)
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package appwrite
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetryCapsServerDelay(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		value  string
+	}{
+		{"retry-after seconds", "Retry-After", "3600"},
+		{"retry-after date", "Retry-After", time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)},
+		{"rate limit reset", "X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if atomic.AddInt32(&calls, 1) == 1 {
+					w.Header().Set(tt.header, tt.value)
+					w.WriteHeader(http.StatusTooManyRequests)
+					fmt.Fprint(w, `{"message":"slow down","code":429}`)
+					return
+				}
+				fmt.Fprint(w, `{}`)
+			}))
+			defer srv.Close()
+
+			var delays []time.Duration
+			policy := DefaultRetryPolicy()
+			policy.MaxBackoff = 50 * time.Millisecond
+			policy.OnRetry = func(event RetryEvent) {
+				delays = append(delays, event.Delay)
+			}
+			client := NewClient()
+			client.SetEndpoint(srv.URL)
+			client.SetRetryPolicy(policy)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if _, err := client.CallAPIContext(ctx, "GET", "/health", nil, nil); err != nil {
+				t.Fatalf("CallAPIContext: %v", err)
+			}
+
+			if calls != 2 {
+				t.Errorf("server called %d times, want 2", calls)
+			}
+			if len(delays) != 1 || delays[0] != policy.MaxBackoff {
+				t.Errorf("retry delays = %v, want [%v]", delays, policy.MaxBackoff)
+			}
+		})
+	}
+}
+
+func TestRetryHonoursShortServerDelay(t *testing.T) {
+	policy := DefaultRetryPolicy()
+	response := &http.Response{
+		StatusCode: http.StatusTooManyRequests,
+		Header:     http.Header{"Retry-After": []string{"2"}},
+	}
+	if delay := policy.backoff(1, response); delay != 2*time.Second {
+		t.Errorf("backoff = %v, want 2s", delay)
+	}
+}
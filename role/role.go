@@ -0,0 +1,114 @@
+// Package role builds and parses the roles Appwrite permissions are granted
+// to.
+package role
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Role kinds
+const (
+	KindAny    = "any"
+	KindGuests = "guests"
+	KindUsers  = "users"
+	KindUser   = "user"
+	KindTeam   = "team"
+	KindMember = "member"
+	KindLabel  = "label"
+)
+
+// Role is the structured form of a role string such as team:abc/owner
+type Role struct {
+	Kind string
+	// ID is the user, team or membership ID, or the label name
+	ID string
+	// Qualifier is the user status for users and user roles, or the team
+	// role for team roles
+	Qualifier string
+}
+
+// Any grants access to anyone, authenticated or not
+func Any() string {
+	return KindAny
+}
+
+// Guests grants access to unauthenticated users only
+func Guests() string {
+	return KindGuests
+}
+
+// Users grants access to every authenticated user. An optional status such as
+// verified or unverified narrows it down.
+func Users(status string) string {
+	return qualify(KindUsers, status)
+}
+
+// User grants access to the user with the given ID. An optional status such
+// as verified or unverified narrows it down.
+func User(id string, status string) string {
+	return qualify(KindUser+":"+id, status)
+}
+
+// Team grants access to every member of the team, or only to the members with
+// the given team role when role isn't empty
+func Team(id string, role string) string {
+	return qualify(KindTeam+":"+id, role)
+}
+
+// Member grants access to the team membership with the given ID
+func Member(id string) string {
+	return KindMember + ":" + id
+}
+
+// Label grants access to the users carrying the given label
+func Label(name string) string {
+	return KindLabel + ":" + name
+}
+
+// String formats the role back into its string form
+func (r Role) String() string {
+	switch r.Kind {
+	case KindAny, KindGuests:
+		return r.Kind
+	case KindUsers:
+		return qualify(r.Kind, r.Qualifier)
+	}
+	return qualify(r.Kind+":"+r.ID, r.Qualifier)
+}
+
+// Parse parses a role string such as any, users/verified or team:abc/owner
+func Parse(s string) (Role, error) {
+	var r Role
+	s, r.Qualifier, _ = strings.Cut(s, "/")
+	r.Kind, r.ID, _ = strings.Cut(s, ":")
+
+	switch r.Kind {
+	case KindAny, KindGuests:
+		if r.ID != "" || r.Qualifier != "" {
+			return Role{}, fmt.Errorf("role: %q takes no ID or qualifier", r.Kind)
+		}
+	case KindUsers:
+		if r.ID != "" {
+			return Role{}, fmt.Errorf("role: %q takes no ID", r.Kind)
+		}
+	case KindUser, KindTeam, KindMember, KindLabel:
+		if r.ID == "" {
+			return Role{}, fmt.Errorf("role: %q requires an ID", r.Kind)
+		}
+		if r.Qualifier != "" && (r.Kind == KindMember || r.Kind == KindLabel) {
+			return Role{}, fmt.Errorf("role: %q takes no qualifier", r.Kind)
+		}
+	default:
+		return Role{}, fmt.Errorf("role: unknown role %q", r.Kind)
+	}
+	return r, nil
+}
+
+// qualify appends the qualifier to role when it isn't empty
+func qualify(role, qualifier string) string {
+	if qualifier == "" {
+		return role
+	}
+	return role + "/" + qualifier
+}
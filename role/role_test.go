@@ -0,0 +1,83 @@
+package role
+
+import "testing"
+
+func TestBuilders(t *testing.T) {
+	tests := []struct {
+		name string
+		role string
+		want string
+	}{
+		{"any", Any(), "any"},
+		{"guests", Guests(), "guests"},
+		{"users", Users(""), "users"},
+		{"verified users", Users("verified"), "users/verified"},
+		{"user", User("u1", ""), "user:u1"},
+		{"unverified user", User("u1", "unverified"), "user:u1/unverified"},
+		{"team", Team("t1", ""), "team:t1"},
+		{"team role", Team("t1", "owner"), "team:t1/owner"},
+		{"member", Member("m1"), "member:m1"},
+		{"label", Label("admin"), "label:admin"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.role != tt.want {
+				t.Errorf("got %s, want %s", tt.role, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Role
+	}{
+		{"any", Role{Kind: KindAny}},
+		{"guests", Role{Kind: KindGuests}},
+		{"users", Role{Kind: KindUsers}},
+		{"users/verified", Role{Kind: KindUsers, Qualifier: "verified"}},
+		{"user:u1", Role{Kind: KindUser, ID: "u1"}},
+		{"user:u1/unverified", Role{Kind: KindUser, ID: "u1", Qualifier: "unverified"}},
+		{"team:t1", Role{Kind: KindTeam, ID: "t1"}},
+		{"team:t1/owner", Role{Kind: KindTeam, ID: "t1", Qualifier: "owner"}},
+		{"member:m1", Role{Kind: KindMember, ID: "m1"}},
+		{"label:admin", Role{Kind: KindLabel, ID: "admin"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := Parse(tt.in)
+			if err != nil {
+				t.Fatalf("Parse: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+			if got.String() != tt.in {
+				t.Errorf("String() = %s, want %s", got.String(), tt.in)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, in := range []string{
+		"",
+		"anyone",
+		"any:x",
+		"any/x",
+		"guests:x",
+		"users:u1",
+		"user",
+		"user:",
+		"team:/owner",
+		"member:m1/owner",
+		"label:admin/x",
+	} {
+		if r, err := Parse(in); err == nil {
+			t.Errorf("Parse(%q) = %+v, want an error", in, r)
+		}
+	}
+}
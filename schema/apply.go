@@ -0,0 +1,283 @@
+package schema
+
+import (
+	"context"
+	"fmt"
+
+	appwrite "github.com/appwrite/sdk-for-go"
+)
+
+// Apply runs every change of the plan in order through the Database
+// service. Attribute and index changes being asynchronous, it waits for the
+// collections they touch to become ready before creating indexes on them and
+// before returning.
+func Apply(ctx context.Context, db *appwrite.Database, plan *Plan) error {
+	// Collections with attribute or index changes still being processed
+	pending := map[[2]string]int{}
+	var touched [][2]string
+
+	wait := func(databaseId, collectionId string, mask int) error {
+		key := [2]string{databaseId, collectionId}
+		if pending[key]&mask == 0 {
+			return nil
+		}
+		if _, err := db.WaitForCollectionReady(ctx, databaseId, collectionId); err != nil {
+			return err
+		}
+		pending[key] = 0
+		return nil
+	}
+	markPending := func(change Change) {
+		key := [2]string{change.DatabaseId, change.CollectionId}
+		if _, seen := pending[key]; !seen {
+			touched = append(touched, key)
+		}
+		if change.Action == ActionDelete {
+			pending[key] |= pendingDelete
+		} else {
+			pending[key] |= pendingCreate
+		}
+	}
+
+	for _, change := range plan.Changes {
+		var err error
+		switch change.Kind {
+		case KindDatabase:
+			err = applyDatabase(ctx, db, change)
+		case KindCollection:
+			err = applyCollection(ctx, db, change)
+		case KindAttribute:
+			// Attributes can be created while others are processed, but not
+			// while one with the same key is still being deleted
+			if err = wait(change.DatabaseId, change.CollectionId, pendingDelete); err == nil {
+				err = applyAttribute(ctx, db, change)
+				markPending(change)
+			}
+		case KindIndex:
+			// Indexes need their attributes to be available
+			if err = wait(change.DatabaseId, change.CollectionId, pendingDelete|pendingCreate); err == nil {
+				err = applyIndex(ctx, db, change)
+				markPending(change)
+			}
+		default:
+			err = fmt.Errorf("unknown kind %q", change.Kind)
+		}
+		if err != nil {
+			return fmt.Errorf("schema: %s: %w", change, err)
+		}
+	}
+
+	for _, key := range touched {
+		if err := wait(key[0], key[1], pendingDelete|pendingCreate); err != nil {
+			return fmt.Errorf("schema: waiting for %s/%s: %w", key[0], key[1], err)
+		}
+	}
+	return nil
+}
+
+// Kinds of asynchronous changes pending on a collection
+const (
+	pendingDelete = 1 << iota
+	pendingCreate
+)
+
+func applyDatabase(ctx context.Context, db *appwrite.Database, change Change) error {
+	var err error
+	switch change.Action {
+	case ActionCreate:
+		_, err = db.CreateDatabaseContext(ctx, change.DatabaseId, change.Database.Name, isEnabled(change.Database.Enabled))
+	case ActionUpdate:
+		_, err = db.UpdateDatabaseContext(ctx, change.DatabaseId, change.Database.Name, isEnabled(change.Database.Enabled))
+	case ActionDelete:
+		err = db.DeleteDatabaseContext(ctx, change.DatabaseId)
+	}
+	return err
+}
+
+func applyCollection(ctx context.Context, db *appwrite.Database, change Change) error {
+	var err error
+	switch change.Action {
+	case ActionCreate:
+		spec := change.Collection
+		_, err = db.CreateCollectionContext(ctx, change.DatabaseId, change.CollectionId, spec.Name, spec.Permissions, spec.DocumentSecurity)
+		if err == nil && !isEnabled(spec.Enabled) {
+			_, err = db.UpdateCollectionContext(ctx, change.DatabaseId, change.CollectionId, spec.Name, spec.Permissions, spec.DocumentSecurity, false)
+		}
+	case ActionUpdate:
+		spec := change.Collection
+		_, err = db.UpdateCollectionContext(ctx, change.DatabaseId, change.CollectionId, spec.Name, spec.Permissions, spec.DocumentSecurity, isEnabled(spec.Enabled))
+	case ActionDelete:
+		err = db.DeleteCollectionContext(ctx, change.DatabaseId, change.CollectionId)
+	}
+	return err
+}
+
+func applyIndex(ctx context.Context, db *appwrite.Database, change Change) error {
+	switch change.Action {
+	case ActionCreate:
+		spec := change.Index
+		var orders []appwrite.IndexOrder
+		for _, order := range spec.Orders {
+			orders = append(orders, appwrite.IndexOrder(order))
+		}
+		_, err := db.CreateIndexContext(ctx, change.DatabaseId, change.CollectionId, spec.Key, appwrite.IndexType(spec.Type), spec.Attributes, orders)
+		return err
+	case ActionDelete:
+		return db.DeleteIndexContext(ctx, change.DatabaseId, change.CollectionId, change.Key)
+	}
+	return fmt.Errorf("indexes can't be updated")
+}
+
+func applyAttribute(ctx context.Context, db *appwrite.Database, change Change) error {
+	switch change.Action {
+	case ActionCreate:
+		return createAttribute(ctx, db, change.DatabaseId, change.CollectionId, change.Attribute)
+	case ActionUpdate:
+		return updateAttribute(ctx, db, change.DatabaseId, change.CollectionId, change.Attribute)
+	case ActionDelete:
+		return db.DeleteAttributeContext(ctx, change.DatabaseId, change.CollectionId, change.Key)
+	}
+	return nil
+}
+
+// createAttribute creates an attribute through the method of its type
+func createAttribute(ctx context.Context, db *appwrite.Database, databaseId, collectionId string, spec *AttributeSpec) error {
+	var err error
+	switch spec.Type {
+	case TypeString, TypeEmail, TypeEnum, TypeIP, TypeURL, TypeDatetime:
+		var def *string
+		if def, err = stringDefault(spec.Key, spec.Default); err != nil {
+			return err
+		}
+		switch spec.Type {
+		case TypeString:
+			_, err = db.CreateStringAttributeContext(ctx, databaseId, collectionId, spec.Key, spec.Size, spec.Required, def, spec.Array)
+		case TypeEmail:
+			_, err = db.CreateEmailAttributeContext(ctx, databaseId, collectionId, spec.Key, spec.Required, def, spec.Array)
+		case TypeEnum:
+			_, err = db.CreateEnumAttributeContext(ctx, databaseId, collectionId, spec.Key, spec.Elements, spec.Required, def, spec.Array)
+		case TypeIP:
+			_, err = db.CreateIPAttributeContext(ctx, databaseId, collectionId, spec.Key, spec.Required, def, spec.Array)
+		case TypeURL:
+			_, err = db.CreateURLAttributeContext(ctx, databaseId, collectionId, spec.Key, spec.Required, def, spec.Array)
+		case TypeDatetime:
+			_, err = db.CreateDatetimeAttributeContext(ctx, databaseId, collectionId, spec.Key, spec.Required, def, spec.Array)
+		}
+	case TypeInteger:
+		var def *int64
+		if def, err = intDefault(spec.Key, spec.Default); err != nil {
+			return err
+		}
+		var lower, upper *int64
+		if lower, err = intBound(spec.Key, "min", spec.Min); err != nil {
+			return err
+		}
+		if upper, err = intBound(spec.Key, "max", spec.Max); err != nil {
+			return err
+		}
+		_, err = db.CreateIntegerAttributeContext(ctx, databaseId, collectionId, spec.Key, spec.Required, lower, upper, def, spec.Array)
+	case TypeFloat:
+		var def *float64
+		if def, err = floatDefault(spec.Key, spec.Default); err != nil {
+			return err
+		}
+		var lower, upper *float64
+		if lower, err = floatBound(spec.Key, "min", spec.Min); err != nil {
+			return err
+		}
+		if upper, err = floatBound(spec.Key, "max", spec.Max); err != nil {
+			return err
+		}
+		_, err = db.CreateFloatAttributeContext(ctx, databaseId, collectionId, spec.Key, spec.Required, lower, upper, def, spec.Array)
+	case TypeBoolean:
+		var def *bool
+		if def, err = boolDefault(spec.Key, spec.Default); err != nil {
+			return err
+		}
+		_, err = db.CreateBooleanAttributeContext(ctx, databaseId, collectionId, spec.Key, spec.Required, def, spec.Array)
+	case TypeRelationship:
+		_, err = db.CreateRelationshipAttributeContext(ctx, databaseId, collectionId, spec.RelatedCollection, spec.RelationType, spec.TwoWay, spec.Key, spec.TwoWayKey, onDelete(spec))
+	default:
+		err = fmt.Errorf("unknown attribute type %q", spec.Type)
+	}
+	return err
+}
+
+// updateAttribute updates the mutable properties of an attribute through the
+// method of its type
+func updateAttribute(ctx context.Context, db *appwrite.Database, databaseId, collectionId string, spec *AttributeSpec) error {
+	var err error
+	switch spec.Type {
+	case TypeString, TypeEmail, TypeEnum, TypeIP, TypeURL, TypeDatetime:
+		var def *string
+		if def, err = stringDefault(spec.Key, spec.Default); err != nil {
+			return err
+		}
+		switch spec.Type {
+		case TypeString:
+			_, err = db.UpdateStringAttributeContext(ctx, databaseId, collectionId, spec.Key, spec.Required, def)
+		case TypeEmail:
+			_, err = db.UpdateEmailAttributeContext(ctx, databaseId, collectionId, spec.Key, spec.Required, def)
+		case TypeEnum:
+			_, err = db.UpdateEnumAttributeContext(ctx, databaseId, collectionId, spec.Key, spec.Elements, spec.Required, def)
+		case TypeIP:
+			_, err = db.UpdateIPAttributeContext(ctx, databaseId, collectionId, spec.Key, spec.Required, def)
+		case TypeURL:
+			_, err = db.UpdateURLAttributeContext(ctx, databaseId, collectionId, spec.Key, spec.Required, def)
+		case TypeDatetime:
+			_, err = db.UpdateDatetimeAttributeContext(ctx, databaseId, collectionId, spec.Key, spec.Required, def)
+		}
+	case TypeInteger:
+		var def *int64
+		if def, err = intDefault(spec.Key, spec.Default); err != nil {
+			return err
+		}
+		var lower, upper *int64
+		if lower, err = intBound(spec.Key, "min", spec.Min); err != nil {
+			return err
+		}
+		if upper, err = intBound(spec.Key, "max", spec.Max); err != nil {
+			return err
+		}
+		if lower == nil || upper == nil {
+			return fmt.Errorf("integer attribute %q needs min and max to be updated", spec.Key)
+		}
+		_, err = db.UpdateIntegerAttributeContext(ctx, databaseId, collectionId, spec.Key, spec.Required, *lower, *upper, def)
+	case TypeFloat:
+		var def *float64
+		if def, err = floatDefault(spec.Key, spec.Default); err != nil {
+			return err
+		}
+		var lower, upper *float64
+		if lower, err = floatBound(spec.Key, "min", spec.Min); err != nil {
+			return err
+		}
+		if upper, err = floatBound(spec.Key, "max", spec.Max); err != nil {
+			return err
+		}
+		if lower == nil || upper == nil {
+			return fmt.Errorf("float attribute %q needs min and max to be updated", spec.Key)
+		}
+		_, err = db.UpdateFloatAttributeContext(ctx, databaseId, collectionId, spec.Key, spec.Required, *lower, *upper, def)
+	case TypeBoolean:
+		var def *bool
+		if def, err = boolDefault(spec.Key, spec.Default); err != nil {
+			return err
+		}
+		_, err = db.UpdateBooleanAttributeContext(ctx, databaseId, collectionId, spec.Key, spec.Required, def)
+	case TypeRelationship:
+		_, err = db.UpdateRelationshipAttributeContext(ctx, databaseId, collectionId, spec.Key, onDelete(spec))
+	default:
+		err = fmt.Errorf("unknown attribute type %q", spec.Type)
+	}
+	return err
+}
+
+// onDelete returns the on-delete behaviour of a relationship, restrict by
+// default
+func onDelete(spec *AttributeSpec) string {
+	if spec.OnDelete == "" {
+		return appwrite.OnDeleteRestrict
+	}
+	return spec.OnDelete
+}
@@ -0,0 +1,235 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+
+	appwrite "github.com/appwrite/sdk-for-go"
+)
+
+// sideChild is the side of the attribute Appwrite creates on the related
+// collection of a two-way relationship
+const sideChild = "child"
+
+// FromAttribute describes a live attribute as an AttributeSpec
+func FromAttribute(attribute appwrite.Attribute) AttributeSpec {
+	spec := AttributeSpec{
+		Key:      attribute.Key,
+		Type:     attribute.Type,
+		Required: attribute.Required,
+		Array:    attribute.Array,
+		Default:  attribute.Default,
+	}
+
+	switch {
+	case attribute.Type == appwrite.AttributeTypeString && attribute.Format != "":
+		spec.Type = attribute.Format
+	case attribute.Type == appwrite.AttributeTypeFloat:
+		spec.Type = TypeFloat
+	}
+
+	switch spec.Type {
+	case TypeString:
+		spec.Size = attribute.Size
+	case TypeInteger, TypeFloat:
+		spec.Min = attribute.Min
+		spec.Max = attribute.Max
+	case TypeEnum:
+		spec.Elements = attribute.Elements
+	case TypeRelationship:
+		spec.RelatedCollection = attribute.RelatedCollection
+		spec.RelationType = attribute.RelationType
+		spec.TwoWay = attribute.TwoWay
+		spec.TwoWayKey = attribute.TwoWayKey
+		spec.OnDelete = attribute.OnDelete
+	}
+	return spec
+}
+
+// FromIndex describes a live index as an IndexSpec
+func FromIndex(index appwrite.Index) IndexSpec {
+	spec := IndexSpec{
+		Key:        index.Key,
+		Type:       string(index.Type),
+		Attributes: index.Attributes,
+	}
+	for _, order := range index.Orders {
+		if order != "" {
+			spec.Orders = append(spec.Orders, string(order))
+		}
+	}
+	return spec
+}
+
+// FromCollection describes a live collection as a CollectionSpec. The
+// attributes Appwrite adds to the related side of two-way relationships are
+// left out, as they are created along with the relationship.
+func FromCollection(collection appwrite.Collection) CollectionSpec {
+	spec := CollectionSpec{
+		Id:               collection.Id,
+		Name:             collection.Name,
+		DocumentSecurity: collection.DocumentSecurity,
+		Permissions:      collection.Permissions,
+	}
+	if !collection.Enabled {
+		spec.Enabled = &collection.Enabled
+	}
+	for _, attribute := range collection.Attributes {
+		if attribute.Side == sideChild {
+			continue
+		}
+		spec.Attributes = append(spec.Attributes, FromAttribute(attribute))
+	}
+	for _, index := range collection.Indexes {
+		spec.Indexes = append(spec.Indexes, FromIndex(index))
+	}
+	return spec
+}
+
+// number reads a number decoded from JSON or YAML as a json.Number written
+// the same way whatever its source: integers exactly, other numbers in their
+// shortest float form
+func number(v interface{}) (json.Number, bool) {
+	var n json.Number
+	switch v := v.(type) {
+	case int:
+		return json.Number(strconv.Itoa(v)), true
+	case int64:
+		return json.Number(strconv.FormatInt(v, 10)), true
+	case uint64:
+		return json.Number(strconv.FormatUint(v, 10)), true
+	case float64:
+		n = json.Number(strconv.FormatFloat(v, 'g', -1, 64))
+	case json.Number:
+		n = v
+	default:
+		return "", false
+	}
+
+	if i, err := strconv.ParseInt(string(n), 10, 64); err == nil {
+		return json.Number(strconv.FormatInt(i, 10)), true
+	}
+	f, err := n.Float64()
+	if err != nil {
+		return "", false
+	}
+	if f == math.Trunc(f) && f >= math.MinInt64 && f < math.MaxInt64 {
+		return json.Number(strconv.FormatInt(int64(f), 10)), true
+	}
+	return json.Number(strconv.FormatFloat(f, 'g', -1, 64)), true
+}
+
+// normalize writes numbers with number so that values decoded from
+// different formats compare equal
+func normalize(v interface{}) interface{} {
+	if n, ok := number(v); ok {
+		return n
+	}
+	if items, ok := v.([]interface{}); ok {
+		out := make([]interface{}, len(items))
+		for i, item := range items {
+			out[i] = normalize(item)
+		}
+		return out
+	}
+	return v
+}
+
+// sameNumber reports whether the optional numbers a and b are equal
+func sameNumber(a, b json.Number) bool {
+	if a == "" || b == "" {
+		return a == b
+	}
+	return normalize(a) == normalize(b)
+}
+
+// toInt64 reads an integer, failing for fractions and for integers out of the
+// int64 range
+func toInt64(v interface{}) (int64, bool) {
+	n, ok := number(v)
+	if !ok {
+		return 0, false
+	}
+	i, err := n.Int64()
+	return i, err == nil
+}
+
+// toFloat64 reads a number
+func toFloat64(v interface{}) (float64, bool) {
+	n, ok := number(v)
+	if !ok {
+		return 0, false
+	}
+	f, err := n.Float64()
+	return f, err == nil
+}
+
+func stringDefault(key string, v interface{}) (*string, error) {
+	if v == nil {
+		return nil, nil
+	}
+	s, ok := v.(string)
+	if !ok {
+		return nil, fmt.Errorf("default of attribute %q must be a string, got %T", key, v)
+	}
+	return &s, nil
+}
+
+func intDefault(key string, v interface{}) (*int64, error) {
+	if v == nil {
+		return nil, nil
+	}
+	i, ok := toInt64(v)
+	if !ok {
+		return nil, fmt.Errorf("default of attribute %q must be an integer, got %v", key, v)
+	}
+	return &i, nil
+}
+
+func floatDefault(key string, v interface{}) (*float64, error) {
+	if v == nil {
+		return nil, nil
+	}
+	f, ok := toFloat64(v)
+	if !ok {
+		return nil, fmt.Errorf("default of attribute %q must be a number, got %v", key, v)
+	}
+	return &f, nil
+}
+
+func boolDefault(key string, v interface{}) (*bool, error) {
+	if v == nil {
+		return nil, nil
+	}
+	b, ok := v.(bool)
+	if !ok {
+		return nil, fmt.Errorf("default of attribute %q must be a boolean, got %T", key, v)
+	}
+	return &b, nil
+}
+
+// intBound reads the optional integer bound name of an attribute
+func intBound(key, name string, n json.Number) (*int64, error) {
+	if n == "" {
+		return nil, nil
+	}
+	i, ok := toInt64(n)
+	if !ok {
+		return nil, fmt.Errorf("%s of attribute %q must be an integer, got %s", name, key, n)
+	}
+	return &i, nil
+}
+
+// floatBound reads the optional bound name of a float attribute
+func floatBound(key, name string, n json.Number) (*float64, error) {
+	if n == "" {
+		return nil, nil
+	}
+	f, ok := toFloat64(n)
+	if !ok {
+		return nil, fmt.Errorf("%s of attribute %q must be a number, got %s", name, key, n)
+	}
+	return &f, nil
+}
@@ -0,0 +1,428 @@
+package schema
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	appwrite "github.com/appwrite/sdk-for-go"
+)
+
+// Action is what a Change does
+type Action string
+
+// Actions
+const (
+	ActionCreate Action = "create"
+	ActionUpdate Action = "update"
+	ActionDelete Action = "delete"
+)
+
+// Kinds of resources a Change applies to
+const (
+	KindDatabase   = "database"
+	KindCollection = "collection"
+	KindAttribute  = "attribute"
+	KindIndex      = "index"
+)
+
+// Options tunes how Diff builds a plan
+type Options struct {
+	// Prune deletes the collections, attributes and indexes missing from the
+	// spec, and recreates the attributes and indexes whose immutable
+	// properties changed. Without it such differences are only reported as
+	// warnings.
+	Prune bool
+}
+
+// Change is a single step of a Plan. Exactly one of Database, Collection,
+// Attribute and Index is set for create and update changes, matching Kind.
+type Change struct {
+	Action       Action
+	Kind         string
+	DatabaseId   string
+	CollectionId string
+	Key          string
+	Details      []string
+
+	Database   *DatabaseSpec
+	Collection *CollectionSpec
+	Attribute  *AttributeSpec
+	Index      *IndexSpec
+}
+
+// String describes the change on a single line
+func (change Change) String() string {
+	var sign string
+	switch change.Action {
+	case ActionCreate:
+		sign = "+"
+	case ActionUpdate:
+		sign = "~"
+	case ActionDelete:
+		sign = "-"
+	}
+
+	target := change.DatabaseId
+	if change.CollectionId != "" {
+		target += "/" + change.CollectionId
+	}
+	if change.Key != "" {
+		target += "." + change.Key
+	}
+
+	line := fmt.Sprintf("%s %s %s %s", sign, change.Action, change.Kind, target)
+	if len(change.Details) > 0 {
+		line += " (" + strings.Join(change.Details, ", ") + ")"
+	}
+	return line
+}
+
+// Plan is the ordered list of changes bringing a project in line with a spec
+type Plan struct {
+	Changes  []Change
+	Warnings []string
+}
+
+// Empty reports whether the plan holds no change
+func (plan *Plan) Empty() bool {
+	return len(plan.Changes) == 0
+}
+
+// String prints the plan as a dry run, one change per line
+func (plan *Plan) String() string {
+	var b strings.Builder
+	for _, change := range plan.Changes {
+		b.WriteString(change.String())
+		b.WriteByte('\n')
+	}
+	for _, warning := range plan.Warnings {
+		b.WriteString("! ")
+		b.WriteString(warning)
+		b.WriteByte('\n')
+	}
+	if plan.Empty() && len(plan.Warnings) == 0 {
+		b.WriteString("No changes\n")
+	}
+	return b.String()
+}
+
+// Phases in which changes are applied, so that every resource exists before
+// the ones depending on it are created
+const (
+	phaseDatabase = iota
+	phaseCollection
+	phaseIndexDelete
+	phaseAttributeDelete
+	phaseAttribute
+	phaseRelationship
+	phaseIndex
+	phaseCollectionDelete
+	phaseCount
+)
+
+// planner accumulates the changes of a plan by phase
+type planner struct {
+	opts     Options
+	phases   [phaseCount][]Change
+	warnings []string
+}
+
+func (p *planner) add(phase int, change Change) {
+	p.phases[phase] = append(p.phases[phase], change)
+}
+
+func (p *planner) warn(format string, args ...interface{}) {
+	p.warnings = append(p.warnings, fmt.Sprintf(format, args...))
+}
+
+// Diff compares the spec against the live databases and returns the plan
+// applying it. Databases missing from the spec are never touched.
+func Diff(ctx context.Context, db *appwrite.Database, spec *Spec, opts Options) (*Plan, error) {
+	p := &planner{opts: opts}
+
+	for i := range spec.Databases {
+		database := &spec.Databases[i]
+		live := map[string]appwrite.Collection{}
+
+		current, err := db.GetDatabaseContext(ctx, database.Id)
+		switch {
+		case errors.Is(err, appwrite.ErrNotFound):
+			p.add(phaseDatabase, Change{Action: ActionCreate, Kind: KindDatabase, DatabaseId: database.Id, Database: database})
+		case err != nil:
+			return nil, err
+		default:
+			var details []string
+			if database.Name != current.Name {
+				details = append(details, fmt.Sprintf("name %q -> %q", current.Name, database.Name))
+			}
+			if isEnabled(database.Enabled) != current.Enabled {
+				details = append(details, fmt.Sprintf("enabled %t -> %t", current.Enabled, isEnabled(database.Enabled)))
+			}
+			if len(details) > 0 {
+				p.add(phaseDatabase, Change{Action: ActionUpdate, Kind: KindDatabase, DatabaseId: database.Id, Details: details, Database: database})
+			}
+
+			for collection, err := range db.AllCollections(ctx, database.Id, nil, 100) {
+				if err != nil {
+					return nil, err
+				}
+				live[collection.Id] = collection
+			}
+		}
+
+		wanted := map[string]bool{}
+		for j := range database.Collections {
+			collection := &database.Collections[j]
+			wanted[collection.Id] = true
+			if existing, ok := live[collection.Id]; ok {
+				p.diffCollection(database.Id, collection, &existing)
+			} else {
+				p.diffCollection(database.Id, collection, nil)
+			}
+		}
+
+		if opts.Prune {
+			for _, id := range sortedKeys(live) {
+				if !wanted[id] {
+					p.add(phaseCollectionDelete, Change{Action: ActionDelete, Kind: KindCollection, DatabaseId: database.Id, CollectionId: id})
+				}
+			}
+		}
+	}
+
+	plan := &Plan{Warnings: p.warnings}
+	for _, changes := range p.phases {
+		plan.Changes = append(plan.Changes, changes...)
+	}
+	return plan, nil
+}
+
+// diffCollection plans the changes of a collection, live being nil when it
+// doesn't exist yet
+func (p *planner) diffCollection(databaseId string, spec *CollectionSpec, live *appwrite.Collection) {
+	base := Change{Kind: KindCollection, DatabaseId: databaseId, CollectionId: spec.Id, Collection: spec}
+	if live == nil {
+		base.Action = ActionCreate
+		p.add(phaseCollection, base)
+		for i := range spec.Attributes {
+			p.createAttribute(databaseId, spec.Id, &spec.Attributes[i])
+		}
+		for i := range spec.Indexes {
+			p.createIndex(databaseId, spec.Id, &spec.Indexes[i], "")
+		}
+		return
+	}
+
+	var details []string
+	if spec.Name != live.Name {
+		details = append(details, fmt.Sprintf("name %q -> %q", live.Name, spec.Name))
+	}
+	if isEnabled(spec.Enabled) != live.Enabled {
+		details = append(details, fmt.Sprintf("enabled %t -> %t", live.Enabled, isEnabled(spec.Enabled)))
+	}
+	if spec.DocumentSecurity != live.DocumentSecurity {
+		details = append(details, fmt.Sprintf("documentSecurity %t -> %t", live.DocumentSecurity, spec.DocumentSecurity))
+	}
+	if !sameSet(spec.Permissions, live.Permissions) {
+		details = append(details, fmt.Sprintf("permissions %v -> %v", live.Permissions, spec.Permissions))
+	}
+	if len(details) > 0 {
+		base.Action = ActionUpdate
+		base.Details = details
+		p.add(phaseCollection, base)
+	}
+
+	liveAttributes := map[string]appwrite.Attribute{}
+	for _, attribute := range live.Attributes {
+		liveAttributes[attribute.Key] = attribute
+	}
+	wantedAttributes := map[string]bool{}
+	for i := range spec.Attributes {
+		attribute := &spec.Attributes[i]
+		wantedAttributes[attribute.Key] = true
+		if current, ok := liveAttributes[attribute.Key]; ok {
+			p.diffAttribute(databaseId, spec.Id, attribute, FromAttribute(current))
+		} else {
+			p.createAttribute(databaseId, spec.Id, attribute)
+		}
+	}
+	if p.opts.Prune {
+		for _, key := range sortedKeys(liveAttributes) {
+			if !wantedAttributes[key] && liveAttributes[key].Side != sideChild {
+				p.deleteAttribute(databaseId, spec.Id, key, "")
+			}
+		}
+	}
+
+	liveIndexes := map[string]appwrite.Index{}
+	for _, index := range live.Indexes {
+		liveIndexes[index.Key] = index
+	}
+	wantedIndexes := map[string]bool{}
+	for i := range spec.Indexes {
+		index := &spec.Indexes[i]
+		wantedIndexes[index.Key] = true
+		current, ok := liveIndexes[index.Key]
+		if !ok {
+			p.createIndex(databaseId, spec.Id, index, "")
+			continue
+		}
+		if details := indexDifferences(index, FromIndex(current)); len(details) > 0 {
+			where := databaseId + "/" + spec.Id + "." + index.Key
+			if !p.opts.Prune {
+				p.warn("index %s differs (%s) and can only be changed by recreating it, rerun with pruning enabled", where, strings.Join(details, ", "))
+				continue
+			}
+			p.deleteIndex(databaseId, spec.Id, index.Key, "recreated")
+			p.createIndex(databaseId, spec.Id, index, strings.Join(details, ", "))
+		}
+	}
+	if p.opts.Prune {
+		for _, key := range sortedKeys(liveIndexes) {
+			if !wantedIndexes[key] {
+				p.deleteIndex(databaseId, spec.Id, key, "")
+			}
+		}
+	}
+}
+
+// diffAttribute plans the changes turning the live attribute into spec
+func (p *planner) diffAttribute(databaseId, collectionId string, spec *AttributeSpec, live AttributeSpec) {
+	var immutable []string
+	if spec.Type != live.Type {
+		immutable = append(immutable, fmt.Sprintf("type %s -> %s", live.Type, spec.Type))
+	}
+	if spec.Array != live.Array {
+		immutable = append(immutable, fmt.Sprintf("array %t -> %t", live.Array, spec.Array))
+	}
+	if spec.Type == TypeString && spec.Size != live.Size {
+		immutable = append(immutable, fmt.Sprintf("size %d -> %d", live.Size, spec.Size))
+	}
+	if spec.Type == TypeRelationship {
+		if spec.RelatedCollection != live.RelatedCollection || spec.RelationType != live.RelationType || spec.TwoWay != live.TwoWay || (spec.TwoWayKey != "" && spec.TwoWayKey != live.TwoWayKey) {
+			immutable = append(immutable, "relation")
+		}
+	}
+	if len(immutable) > 0 {
+		where := databaseId + "/" + collectionId + "." + spec.Key
+		if !p.opts.Prune {
+			p.warn("attribute %s differs (%s) and can only be changed by recreating it, losing its data; rerun with pruning enabled", where, strings.Join(immutable, ", "))
+			return
+		}
+		p.deleteAttribute(databaseId, collectionId, spec.Key, "recreated")
+		p.createAttribute(databaseId, collectionId, spec)
+		return
+	}
+
+	var details []string
+	if spec.Required != live.Required {
+		details = append(details, fmt.Sprintf("required %t -> %t", live.Required, spec.Required))
+	}
+	if !reflect.DeepEqual(normalize(spec.Default), normalize(live.Default)) {
+		details = append(details, fmt.Sprintf("default %v -> %v", live.Default, spec.Default))
+	}
+	if spec.Min != "" && !sameNumber(spec.Min, live.Min) {
+		details = append(details, fmt.Sprintf("min -> %s", spec.Min))
+	}
+	if spec.Max != "" && !sameNumber(spec.Max, live.Max) {
+		details = append(details, fmt.Sprintf("max -> %s", spec.Max))
+	}
+	if spec.Type == TypeEnum && !reflect.DeepEqual(spec.Elements, live.Elements) {
+		details = append(details, fmt.Sprintf("elements %v -> %v", live.Elements, spec.Elements))
+	}
+	if spec.Type == TypeRelationship && spec.OnDelete != "" && spec.OnDelete != live.OnDelete {
+		details = append(details, fmt.Sprintf("onDelete %s -> %s", live.OnDelete, spec.OnDelete))
+	}
+	if len(details) == 0 {
+		return
+	}
+
+	// Updates require every bound, keep the live ones the spec leaves out
+	update := *spec
+	if update.Min == "" {
+		update.Min = live.Min
+	}
+	if update.Max == "" {
+		update.Max = live.Max
+	}
+	phase := phaseAttribute
+	if spec.Type == TypeRelationship {
+		phase = phaseRelationship
+	}
+	p.add(phase, Change{Action: ActionUpdate, Kind: KindAttribute, DatabaseId: databaseId, CollectionId: collectionId, Key: spec.Key, Details: details, Attribute: &update})
+}
+
+func (p *planner) createAttribute(databaseId, collectionId string, spec *AttributeSpec) {
+	phase := phaseAttribute
+	details := []string{spec.Type}
+	if spec.Type == TypeRelationship {
+		phase = phaseRelationship
+		details = append(details, spec.RelationType+" "+spec.RelatedCollection)
+	}
+	p.add(phase, Change{Action: ActionCreate, Kind: KindAttribute, DatabaseId: databaseId, CollectionId: collectionId, Key: spec.Key, Details: details, Attribute: spec})
+}
+
+func (p *planner) deleteAttribute(databaseId, collectionId, key, detail string) {
+	change := Change{Action: ActionDelete, Kind: KindAttribute, DatabaseId: databaseId, CollectionId: collectionId, Key: key}
+	if detail != "" {
+		change.Details = []string{detail}
+	}
+	p.add(phaseAttributeDelete, change)
+}
+
+func (p *planner) createIndex(databaseId, collectionId string, spec *IndexSpec, detail string) {
+	details := []string{spec.Type + " on " + strings.Join(spec.Attributes, ", ")}
+	if detail != "" {
+		details = append(details, detail)
+	}
+	p.add(phaseIndex, Change{Action: ActionCreate, Kind: KindIndex, DatabaseId: databaseId, CollectionId: collectionId, Key: spec.Key, Details: details, Index: spec})
+}
+
+func (p *planner) deleteIndex(databaseId, collectionId, key, detail string) {
+	change := Change{Action: ActionDelete, Kind: KindIndex, DatabaseId: databaseId, CollectionId: collectionId, Key: key}
+	if detail != "" {
+		change.Details = []string{detail}
+	}
+	p.add(phaseIndexDelete, change)
+}
+
+// indexDifferences lists how the live index differs from spec. Orders are
+// only compared when the spec sets them.
+func indexDifferences(spec *IndexSpec, live IndexSpec) []string {
+	var details []string
+	if spec.Type != live.Type {
+		details = append(details, fmt.Sprintf("type %s -> %s", live.Type, spec.Type))
+	}
+	if !reflect.DeepEqual(spec.Attributes, live.Attributes) {
+		details = append(details, fmt.Sprintf("attributes %v -> %v", live.Attributes, spec.Attributes))
+	}
+	if len(spec.Orders) > 0 && !strings.EqualFold(strings.Join(spec.Orders, ","), strings.Join(live.Orders, ",")) {
+		details = append(details, fmt.Sprintf("orders %v -> %v", live.Orders, spec.Orders))
+	}
+	return details
+}
+
+// sameSet reports whether a and b hold the same strings in any order
+func sameSet(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	sa := append([]string{}, a...)
+	sb := append([]string{}, b...)
+	sort.Strings(sa)
+	sort.Strings(sb)
+	return reflect.DeepEqual(sa, sb)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,238 @@
+package schema
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	appwrite "github.com/appwrite/sdk-for-go"
+)
+
+// fakeProject serves the databases and collections Diff reads
+func fakeProject(t *testing.T, collections map[string][]appwrite.Collection) *appwrite.Database {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+		if r.Method != http.MethodGet || len(parts) < 2 || parts[0] != "databases" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			http.Error(w, "unexpected request", http.StatusBadRequest)
+			return
+		}
+		live, ok := collections[parts[1]]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(map[string]interface{}{"message": "Database not found", "code": 404, "type": "database_not_found"})
+			return
+		}
+		switch {
+		case len(parts) == 2:
+			json.NewEncoder(w).Encode(appwrite.DatabaseObject{Id: parts[1], Name: "Main", Enabled: true})
+		case strings.Contains(r.URL.RawQuery, "cursorAfter"):
+			json.NewEncoder(w).Encode(appwrite.CollectionList{Total: len(live)})
+		default:
+			json.NewEncoder(w).Encode(appwrite.CollectionList{Total: len(live), Collections: live})
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	client := appwrite.NewClient()
+	client.SetEndpoint(srv.URL)
+	db := appwrite.NewDatabase(client)
+	return &db
+}
+
+func attribute(key, typ string) appwrite.Attribute {
+	return appwrite.Attribute{AttributeOptions: appwrite.AttributeOptions{Key: key, Type: typ, Status: "available"}}
+}
+
+func stringAttribute(key string, size int, required bool) appwrite.Attribute {
+	a := attribute(key, appwrite.AttributeTypeString)
+	a.Size = size
+	a.Required = required
+	return a
+}
+
+func relationship(key, related, onDelete string) appwrite.Attribute {
+	a := attribute(key, appwrite.AttributeTypeRelationship)
+	a.RelatedCollection = related
+	a.RelationType = "manyToOne"
+	a.OnDelete = onDelete
+	a.Side = "parent"
+	return a
+}
+
+func index(key string, attributes ...string) appwrite.Index {
+	return appwrite.Index{Key: key, Type: "key", Status: "available", Attributes: attributes}
+}
+
+// postsSpec is the spec the live collections of the tests are compared with
+var postsSpec = CollectionSpec{
+	Id:   "posts",
+	Name: "Posts",
+	Attributes: []AttributeSpec{
+		{Key: "author", Type: TypeRelationship, RelatedCollection: "users", RelationType: "manyToOne", OnDelete: "cascade"},
+		{Key: "title", Type: TypeString, Size: 128, Required: true},
+		{Key: "views", Type: TypeInteger},
+	},
+	Indexes: []IndexSpec{
+		{Key: "by_title", Type: "key", Attributes: []string{"title"}},
+	},
+}
+
+func TestDiff(t *testing.T) {
+	users := appwrite.Collection{Id: "users", Name: "Users", Enabled: true}
+
+	tests := []struct {
+		name     string
+		live     map[string][]appwrite.Collection
+		opts     Options
+		changes  []string
+		warnings int
+	}{
+		{
+			name: "create database",
+			live: map[string][]appwrite.Collection{},
+			changes: []string{
+				"+ create database main",
+				"+ create collection main/posts",
+				"+ create collection main/users",
+				"+ create attribute main/posts.title (string)",
+				"+ create attribute main/posts.views (integer)",
+				"+ create attribute main/posts.author (relationship, manyToOne users)",
+				"+ create index main/posts.by_title (key on title)",
+			},
+		},
+		{
+			name: "in sync",
+			live: map[string][]appwrite.Collection{"main": {users, {
+				Id: "posts", Name: "Posts", Enabled: true,
+				Attributes: []appwrite.Attribute{
+					relationship("author", "users", "cascade"),
+					stringAttribute("title", 128, true),
+					attribute("views", "integer"),
+				},
+				Indexes: []appwrite.Index{index("by_title", "title")},
+			}}},
+		},
+		{
+			name: "update",
+			live: map[string][]appwrite.Collection{"main": {users, {
+				Id: "posts", Name: "Articles", Enabled: true,
+				Attributes: []appwrite.Attribute{
+					relationship("author", "users", "restrict"),
+					stringAttribute("title", 128, false),
+				},
+			}}},
+			changes: []string{
+				`~ update collection main/posts (name "Articles" -> "Posts")`,
+				"~ update attribute main/posts.title (required false -> true)",
+				"+ create attribute main/posts.views (integer)",
+				"~ update attribute main/posts.author (onDelete restrict -> cascade)",
+				"+ create index main/posts.by_title (key on title)",
+			},
+		},
+		{
+			name: "prune",
+			live: map[string][]appwrite.Collection{"main": {users, {Id: "drafts", Name: "Drafts", Enabled: true}, {
+				Id: "posts", Name: "Posts", Enabled: true,
+				Attributes: []appwrite.Attribute{
+					relationship("author", "users", "cascade"),
+					attribute("title", "integer"),
+					attribute("views", "integer"),
+					attribute("legacy", "string"),
+				},
+				Indexes: []appwrite.Index{index("by_title", "views"), index("by_legacy", "legacy")},
+			}}},
+			opts: Options{Prune: true},
+			changes: []string{
+				"- delete index main/posts.by_title (recreated)",
+				"- delete index main/posts.by_legacy",
+				"- delete attribute main/posts.title (recreated)",
+				"- delete attribute main/posts.legacy",
+				"+ create attribute main/posts.title (string)",
+				"+ create index main/posts.by_title (key on title, attributes [views] -> [title])",
+				"- delete collection main/drafts",
+			},
+		},
+		{
+			name: "immutable changes without pruning",
+			live: map[string][]appwrite.Collection{"main": {users, {
+				Id: "posts", Name: "Posts", Enabled: true,
+				Attributes: []appwrite.Attribute{
+					relationship("author", "users", "cascade"),
+					attribute("title", "integer"),
+					attribute("views", "integer"),
+					attribute("legacy", "string"),
+				},
+				Indexes: []appwrite.Index{index("by_title", "views")},
+			}}},
+			warnings: 2,
+		},
+	}
+
+	spec := &Spec{Databases: []DatabaseSpec{{Id: "main", Name: "Main", Collections: []CollectionSpec{postsSpec, {Id: "users", Name: "Users"}}}}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan, err := Diff(context.Background(), fakeProject(t, tt.live), spec, tt.opts)
+			if err != nil {
+				t.Fatalf("Diff: %v", err)
+			}
+			var changes []string
+			for _, change := range plan.Changes {
+				changes = append(changes, change.String())
+			}
+			if !reflect.DeepEqual(changes, tt.changes) {
+				t.Errorf("changes:\n%s\nwant:\n%s", strings.Join(changes, "\n"), strings.Join(tt.changes, "\n"))
+			}
+			if len(plan.Warnings) != tt.warnings {
+				t.Errorf("warnings = %q, want %d", plan.Warnings, tt.warnings)
+			}
+		})
+	}
+}
+
+func TestDiffKeepsLargeIntegers(t *testing.T) {
+	views := attribute("views", appwrite.AttributeTypeInteger)
+	views.Min = "-9223372036854775808"
+	views.Max = "9223372036854775807"
+	views.Default = json.Number("9007199254740993")
+	live := map[string][]appwrite.Collection{"main": {{Id: "posts", Name: "Posts", Enabled: true, Attributes: []appwrite.Attribute{views}}}}
+
+	tests := []struct {
+		name    string
+		spec    AttributeSpec
+		changes []string
+	}{
+		{"same", AttributeSpec{Key: "views", Type: TypeInteger, Min: "-9223372036854775808", Max: "9223372036854775807", Default: 9007199254740993}, nil},
+		{"bounds left out", AttributeSpec{Key: "views", Type: TypeInteger, Default: json.Number("9007199254740993")}, nil},
+		{"max", AttributeSpec{Key: "views", Type: TypeInteger, Max: "9223372036854775806", Default: json.Number("9007199254740993")}, []string{
+			"~ update attribute main/posts.views (max -> 9223372036854775806)",
+		}},
+		{"default", AttributeSpec{Key: "views", Type: TypeInteger, Default: json.Number("9007199254740992")}, []string{
+			"~ update attribute main/posts.views (default 9007199254740993 -> 9007199254740992)",
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := &Spec{Databases: []DatabaseSpec{{Id: "main", Name: "Main", Collections: []CollectionSpec{{
+				Id: "posts", Name: "Posts", Attributes: []AttributeSpec{tt.spec},
+			}}}}}
+			plan, err := Diff(context.Background(), fakeProject(t, live), spec, Options{})
+			if err != nil {
+				t.Fatalf("Diff: %v", err)
+			}
+			var changes []string
+			for _, change := range plan.Changes {
+				changes = append(changes, change.String())
+			}
+			if !reflect.DeepEqual(changes, tt.changes) {
+				t.Errorf("changes = %q, want %q", changes, tt.changes)
+			}
+		})
+	}
+}
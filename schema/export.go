@@ -0,0 +1,171 @@
+package schema
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"sort"
+
+	appwrite "github.com/appwrite/sdk-for-go"
+	"gopkg.in/yaml.v3"
+)
+
+// ExportSchema describes a live database, its collections, attributes,
+// indexes and permissions as a spec, sorted so that exports of the same
+// schema are identical
+func ExportSchema(ctx context.Context, db *appwrite.Database, databaseId string) (*Spec, error) {
+	database, err := exportDatabase(ctx, db, databaseId)
+	if err != nil {
+		return nil, err
+	}
+	return &Spec{Databases: []DatabaseSpec{*database}}, nil
+}
+
+// Export describes the given live databases as a spec, or every database of
+// the project when none is given
+func Export(ctx context.Context, db *appwrite.Database, databaseIds ...string) (*Spec, error) {
+	if len(databaseIds) == 0 {
+		for database, err := range db.AllDatabases(ctx, nil, 100) {
+			if err != nil {
+				return nil, err
+			}
+			databaseIds = append(databaseIds, database.Id)
+		}
+	}
+
+	spec := &Spec{Databases: []DatabaseSpec{}}
+	for _, databaseId := range databaseIds {
+		database, err := exportDatabase(ctx, db, databaseId)
+		if err != nil {
+			return nil, err
+		}
+		spec.Databases = append(spec.Databases, *database)
+	}
+	spec.Sort()
+	return spec, nil
+}
+
+func exportDatabase(ctx context.Context, db *appwrite.Database, databaseId string) (*DatabaseSpec, error) {
+	live, err := db.GetDatabaseContext(ctx, databaseId)
+	if err != nil {
+		return nil, err
+	}
+
+	database := &DatabaseSpec{
+		Id:          live.Id,
+		Name:        live.Name,
+		Collections: []CollectionSpec{},
+	}
+	if !live.Enabled {
+		database.Enabled = &live.Enabled
+	}
+	for collection, err := range db.AllCollections(ctx, databaseId, nil, 100) {
+		if err != nil {
+			return nil, err
+		}
+		database.Collections = append(database.Collections, FromCollection(collection))
+	}
+	database.sort()
+	return database, nil
+}
+
+// Sort orders databases and collections by id, attributes and indexes by key
+// and permissions alphabetically. The attributes of an index and the
+// elements of an enum keep their order, which is meaningful.
+func (spec *Spec) Sort() {
+	sort.Slice(spec.Databases, func(i, j int) bool {
+		return spec.Databases[i].Id < spec.Databases[j].Id
+	})
+	for i := range spec.Databases {
+		spec.Databases[i].sort()
+	}
+}
+
+func (database *DatabaseSpec) sort() {
+	sort.Slice(database.Collections, func(i, j int) bool {
+		return database.Collections[i].Id < database.Collections[j].Id
+	})
+	for i := range database.Collections {
+		collection := &database.Collections[i]
+		sort.Strings(collection.Permissions)
+		sort.Slice(collection.Attributes, func(i, j int) bool {
+			return collection.Attributes[i].Key < collection.Attributes[j].Key
+		})
+		sort.Slice(collection.Indexes, func(i, j int) bool {
+			return collection.Indexes[i].Key < collection.Indexes[j].Key
+		})
+	}
+}
+
+// Write encodes the spec with the given marshal function, such as
+// MarshalYAML, or as indented JSON when marshal is nil
+func (spec *Spec) Write(w io.Writer, marshal func(interface{}) ([]byte, error)) error {
+	if marshal == nil {
+		marshal = func(v interface{}) ([]byte, error) {
+			data, err := json.MarshalIndent(v, "", "  ")
+			return append(data, '\n'), err
+		}
+	}
+	data, err := marshal(spec)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// Save writes the spec to a file that Load reads back, as YAML when its
+// extension is .yaml or .yml and as JSON otherwise
+func (spec *Spec) Save(path string) error {
+	var marshal func(interface{}) ([]byte, error)
+	if isYAML(path) {
+		marshal = MarshalYAML
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := spec.Write(f, marshal); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// MarshalYAML encodes v as YAML following its JSON encoding, so that fields
+// are named and omitted as in JSON and json.Number values are written as
+// numbers rather than strings
+func MarshalYAML(v interface{}) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	// JSON being YAML, decode it to a node and drop its JSON styling
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return nil, err
+	}
+	clearStyle(&node)
+
+	var b bytes.Buffer
+	enc := yaml.NewEncoder(&b)
+	enc.SetIndent(2)
+	if err := enc.Encode(&node); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// clearStyle resets the style of node and its children, letting the encoder
+// pick the block style and quote only the strings that need it
+func clearStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		clearStyle(child)
+	}
+}
@@ -0,0 +1,232 @@
+// Package schema keeps Appwrite databases in sync with a declarative spec of
+// their collections, attributes, indexes and permissions.
+//
+// A spec is loaded from a JSON or YAML file with Load, compared against a live project with
+// Diff and the resulting Plan is either printed as a dry run or applied with
+// Apply:
+//
+//	spec, err := schema.Load("schema.json")
+//	plan, err := schema.Diff(ctx, &db, spec, schema.Options{})
+//	fmt.Print(plan)
+//	err = schema.Apply(ctx, &db, plan)
+//
+// The other way round, ExportSchema describes a live database as a spec that
+// can be saved and committed alongside the code using it:
+//
+//	spec, err := schema.ExportSchema(ctx, &db, "production")
+//	err = spec.Save("schema.json")
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Attribute types used in specs
+const (
+	TypeString       = "string"
+	TypeInteger      = "integer"
+	TypeFloat        = "float"
+	TypeBoolean      = "boolean"
+	TypeDatetime     = "datetime"
+	TypeEmail        = "email"
+	TypeEnum         = "enum"
+	TypeIP           = "ip"
+	TypeURL          = "url"
+	TypeRelationship = "relationship"
+)
+
+// Spec describes the databases of a project
+type Spec struct {
+	Databases []DatabaseSpec `json:"databases" yaml:"databases"`
+}
+
+// DatabaseSpec describes a database and its collections
+type DatabaseSpec struct {
+	Id          string           `json:"id" yaml:"id"`
+	Name        string           `json:"name" yaml:"name"`
+	Enabled     *bool            `json:"enabled,omitempty" yaml:"enabled,omitempty"`
+	Collections []CollectionSpec `json:"collections" yaml:"collections"`
+}
+
+// CollectionSpec describes a collection, its attributes and indexes
+type CollectionSpec struct {
+	Id               string          `json:"id" yaml:"id"`
+	Name             string          `json:"name" yaml:"name"`
+	Enabled          *bool           `json:"enabled,omitempty" yaml:"enabled,omitempty"`
+	DocumentSecurity bool            `json:"documentSecurity,omitempty" yaml:"documentSecurity,omitempty"`
+	Permissions      []string        `json:"permissions,omitempty" yaml:"permissions,omitempty"`
+	Attributes       []AttributeSpec `json:"attributes,omitempty" yaml:"attributes,omitempty"`
+	Indexes          []IndexSpec     `json:"indexes,omitempty" yaml:"indexes,omitempty"`
+}
+
+// AttributeSpec describes an attribute. Type is one of the Type constants;
+// Size only applies to strings, Min and Max to integers and floats, Elements
+// to enums and the relation fields to relationships. Numbers are kept as
+// json.Number so that 64-bit bounds and defaults aren't rounded.
+type AttributeSpec struct {
+	Key      string      `json:"key" yaml:"key"`
+	Type     string      `json:"type" yaml:"type"`
+	Required bool        `json:"required,omitempty" yaml:"required,omitempty"`
+	Array    bool        `json:"array,omitempty" yaml:"array,omitempty"`
+	Size     int         `json:"size,omitempty" yaml:"size,omitempty"`
+	Default  interface{} `json:"default,omitempty" yaml:"default,omitempty"`
+	Min      json.Number `json:"min,omitempty" yaml:"min,omitempty"`
+	Max      json.Number `json:"max,omitempty" yaml:"max,omitempty"`
+	Elements []string    `json:"elements,omitempty" yaml:"elements,omitempty"`
+
+	RelatedCollection string `json:"relatedCollection,omitempty" yaml:"relatedCollection,omitempty"`
+	RelationType      string `json:"relationType,omitempty" yaml:"relationType,omitempty"`
+	TwoWay            bool   `json:"twoWay,omitempty" yaml:"twoWay,omitempty"`
+	TwoWayKey         string `json:"twoWayKey,omitempty" yaml:"twoWayKey,omitempty"`
+	OnDelete          string `json:"onDelete,omitempty" yaml:"onDelete,omitempty"`
+}
+
+// IndexSpec describes an index
+type IndexSpec struct {
+	Key        string   `json:"key" yaml:"key"`
+	Type       string   `json:"type" yaml:"type"`
+	Attributes []string `json:"attributes" yaml:"attributes"`
+	Orders     []string `json:"orders,omitempty" yaml:"orders,omitempty"`
+}
+
+// Load reads a spec file, decoded as YAML when its extension is .yaml or
+// .yml and as JSON otherwise
+func Load(path string) (*Spec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if isYAML(path) {
+		return Parse(data, yaml.Unmarshal)
+	}
+	return Parse(data, UnmarshalJSON)
+}
+
+// Parse decodes a spec with the given unmarshal function, such as
+// UnmarshalJSON or yaml.Unmarshal, and validates it
+func Parse(data []byte, unmarshal func([]byte, interface{}) error) (*Spec, error) {
+	var spec Spec
+	if err := unmarshal(data, &spec); err != nil {
+		return nil, err
+	}
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+	return &spec, nil
+}
+
+// Validate checks that the spec is complete and holds no duplicates
+func (spec *Spec) Validate() error {
+	databases := map[string]bool{}
+	for _, database := range spec.Databases {
+		if database.Id == "" {
+			return fmt.Errorf("schema: database without id")
+		}
+		if databases[database.Id] {
+			return fmt.Errorf("schema: duplicate database %q", database.Id)
+		}
+		databases[database.Id] = true
+
+		collections := map[string]bool{}
+		for _, collection := range database.Collections {
+			where := database.Id + "/" + collection.Id
+			if collection.Id == "" {
+				return fmt.Errorf("schema: collection without id in database %q", database.Id)
+			}
+			if collections[collection.Id] {
+				return fmt.Errorf("schema: duplicate collection %q", where)
+			}
+			collections[collection.Id] = true
+
+			attributes := map[string]bool{}
+			for _, attribute := range collection.Attributes {
+				if err := attribute.validate(); err != nil {
+					return fmt.Errorf("schema: %s: %w", where, err)
+				}
+				if attributes[attribute.Key] {
+					return fmt.Errorf("schema: %s: duplicate attribute %q", where, attribute.Key)
+				}
+				attributes[attribute.Key] = true
+			}
+
+			indexes := map[string]bool{}
+			for _, index := range collection.Indexes {
+				if index.Key == "" || len(index.Attributes) == 0 {
+					return fmt.Errorf("schema: %s: index needs a key and attributes", where)
+				}
+				if indexes[index.Key] {
+					return fmt.Errorf("schema: %s: duplicate index %q", where, index.Key)
+				}
+				indexes[index.Key] = true
+			}
+		}
+	}
+	return nil
+}
+
+func (attribute *AttributeSpec) validate() error {
+	if attribute.Key == "" {
+		return fmt.Errorf("attribute without key")
+	}
+	switch attribute.Type {
+	case TypeString:
+		if attribute.Size <= 0 {
+			return fmt.Errorf("string attribute %q needs a size", attribute.Key)
+		}
+	case TypeEnum:
+		if len(attribute.Elements) == 0 {
+			return fmt.Errorf("enum attribute %q needs elements", attribute.Key)
+		}
+	case TypeRelationship:
+		if attribute.RelatedCollection == "" || attribute.RelationType == "" {
+			return fmt.Errorf("relationship attribute %q needs a related collection and a relation type", attribute.Key)
+		}
+	case TypeInteger:
+		if _, err := intBound(attribute.Key, "min", attribute.Min); err != nil {
+			return err
+		}
+		if _, err := intBound(attribute.Key, "max", attribute.Max); err != nil {
+			return err
+		}
+	case TypeFloat:
+		if _, err := floatBound(attribute.Key, "min", attribute.Min); err != nil {
+			return err
+		}
+		if _, err := floatBound(attribute.Key, "max", attribute.Max); err != nil {
+			return err
+		}
+	case TypeBoolean, TypeDatetime, TypeEmail, TypeIP, TypeURL:
+	default:
+		return fmt.Errorf("attribute %q has unknown type %q", attribute.Key, attribute.Type)
+	}
+	return nil
+}
+
+// UnmarshalJSON is json.Unmarshal decoding numbers as json.Number, so that
+// large integer defaults aren't rounded. Pass it to Parse to decode JSON specs.
+func UnmarshalJSON(data []byte, v interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	return dec.Decode(v)
+}
+
+// isYAML reports whether path names a YAML file
+func isYAML(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return true
+	}
+	return false
+}
+
+// isEnabled reads an optional enabled flag, which defaults to true
+func isEnabled(enabled *bool) bool {
+	return enabled == nil || *enabled
+}
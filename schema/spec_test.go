@@ -0,0 +1,89 @@
+package schema
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLoadYAML(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "schema.yml")
+	err := os.WriteFile(path, []byte(`databases:
+  - id: main
+    name: Main
+    collections:
+      - id: posts
+        name: Posts
+        permissions: ['read("any")']
+        attributes:
+          - key: title
+            type: string
+            size: 128
+            required: true
+        indexes:
+          - key: by_title
+            type: key
+            attributes: [title]
+`), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	spec, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	want := &Spec{Databases: []DatabaseSpec{{Id: "main", Name: "Main", Collections: []CollectionSpec{{
+		Id:          "posts",
+		Name:        "Posts",
+		Permissions: []string{`read("any")`},
+		Attributes:  []AttributeSpec{{Key: "title", Type: TypeString, Size: 128, Required: true}},
+		Indexes:     []IndexSpec{{Key: "by_title", Type: "key", Attributes: []string{"title"}}},
+	}}}}}
+	if !reflect.DeepEqual(spec, want) {
+		t.Errorf("Load = %+v, want %+v", spec, want)
+	}
+}
+
+func TestSaveKeepsLargeNumbers(t *testing.T) {
+	spec := &Spec{Databases: []DatabaseSpec{{Id: "main", Name: "Main", Collections: []CollectionSpec{{
+		Id:   "posts",
+		Name: "Posts",
+		Attributes: []AttributeSpec{
+			{Key: "code", Type: TypeString, Size: 8, Default: "10"},
+			{Key: "views", Type: TypeInteger, Min: "-9223372036854775808", Max: "9223372036854775807", Default: json.Number("9007199254740993")},
+		},
+	}}}}}
+
+	for _, name := range []string{"schema.json", "schema.yaml"} {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), name)
+			if err := spec.Save(path); err != nil {
+				t.Fatalf("Save: %v", err)
+			}
+			data, _ := os.ReadFile(path)
+			if !strings.Contains(string(data), "9223372036854775807") || strings.Contains(string(data), `"9223372036854775807"`) {
+				t.Errorf("max isn't written as a number:\n%s", data)
+			}
+
+			loaded, err := Load(path)
+			if err != nil {
+				t.Fatalf("Load: %v", err)
+			}
+			attributes := loaded.Databases[0].Collections[0].Attributes
+			if attributes[0].Default != "10" {
+				t.Errorf("string default = %#v, want \"10\"", attributes[0].Default)
+			}
+			views := attributes[1]
+			if views.Min != "-9223372036854775808" || views.Max != "9223372036854775807" {
+				t.Errorf("bounds = %s, %s, want the int64 range", views.Min, views.Max)
+			}
+			if got := normalize(views.Default); got != json.Number("9007199254740993") {
+				t.Errorf("default = %#v, want 9007199254740993", views.Default)
+			}
+		})
+	}
+}
@@ -1,8 +1,15 @@
 package appwrite
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
-	"strings"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/appwrite/sdk-for-go/id"
 )
 
 // Storage service
@@ -60,6 +67,11 @@ type FileListResponse struct {
 // ListBuckets get all the bucket in the project. This endpoint response returns a JSON
 // object with the list of bucket objects.
 func (srv *Storage) ListBuckets(Search string, Limit int, Offset int, OrderType string) (*BucketListResponse, error) {
+	return srv.ListBucketsContext(context.Background(), Search, Limit, Offset, OrderType)
+}
+
+// ListBucketsContext is like ListBuckets but cancels the request when ctx is done.
+func (srv *Storage) ListBucketsContext(ctx context.Context, Search string, Limit int, Offset int, OrderType string) (*BucketListResponse, error) {
 	path := "/storage/buckets/"
 
 	params := map[string]interface{}{
@@ -69,7 +81,7 @@ func (srv *Storage) ListBuckets(Search string, Limit int, Offset int, OrderType
 		"orderType": OrderType,
 	}
 
-	resp, err := srv.Client.CallAPI("GET", path, srv.Client.headers, params)
+	resp, err := srv.Client.CallAPIContext(ctx, "GET", path, srv.Client.headers, params)
 	if err != nil {
 		return nil, err
 	}
@@ -83,12 +95,53 @@ func (srv *Storage) ListBuckets(Search string, Limit int, Offset int, OrderType
 // GetBucket get bucket by its unique ID. This endpoint response returns a JSON
 // object with the bucket metadata.
 func (srv *Storage) GetBucket(bucketId string) (*Bucket, error) {
-	r := strings.NewReplacer("{bucketId}", bucketId)
+	return srv.GetBucketContext(context.Background(), bucketId)
+}
+
+// GetBucketContext is like GetBucket but cancels the request when ctx is done.
+func (srv *Storage) GetBucketContext(ctx context.Context, bucketId string) (*Bucket, error) {
+	r := pathReplacer("{bucketId}", bucketId)
 	path := r.Replace("/storage/buckets/{bucketId}")
 
 	params := map[string]interface{}{}
 
-	resp, err := srv.Client.CallAPI("GET", path, srv.Client.headers, params)
+	resp, err := srv.Client.CallAPIContext(ctx, "GET", path, srv.Client.headers, params)
+	if err != nil {
+		return nil, err
+	}
+	var result Bucket
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// CreateBucket create a new storage bucket.
+func (srv *Storage) CreateBucket(bucketId, Name string, Permissions []string, FileSecurity bool, Enabled bool, MaximumFileSize int, AllowedFileExtensions []string, Compression string, Encryption bool, Antivirus bool) (*Bucket, error) {
+	return srv.CreateBucketContext(context.Background(), bucketId, Name, Permissions, FileSecurity, Enabled, MaximumFileSize, AllowedFileExtensions, Compression, Encryption, Antivirus)
+}
+
+// CreateBucketContext is like CreateBucket but cancels the request when ctx is done.
+func (srv *Storage) CreateBucketContext(ctx context.Context, bucketId, Name string, Permissions []string, FileSecurity bool, Enabled bool, MaximumFileSize int, AllowedFileExtensions []string, Compression string, Encryption bool, Antivirus bool) (*Bucket, error) {
+	if err := validateId("bucketId", bucketId); err != nil {
+		return nil, err
+	}
+	path := "/storage/buckets"
+
+	params := map[string]interface{}{
+		"bucketId":              bucketId,
+		"name":                  Name,
+		"permissions":           Permissions,
+		"fileSecurity":          FileSecurity,
+		"enabled":               Enabled,
+		"maximumFileSize":       MaximumFileSize,
+		"allowedFileExtensions": AllowedFileExtensions,
+		"compression":           Compression,
+		"encryption":            Encryption,
+		"antivirus":             Antivirus,
+	}
+
+	resp, err := srv.Client.CallAPIContext(ctx, "POST", path, srv.Client.headers, params)
 	if err != nil {
 		return nil, err
 	}
@@ -99,11 +152,65 @@ func (srv *Storage) GetBucket(bucketId string) (*Bucket, error) {
 	return &result, nil
 }
 
+// UpdateBucket update a storage bucket by its unique ID.
+func (srv *Storage) UpdateBucket(bucketId, Name string, Permissions []string, FileSecurity bool, Enabled bool, MaximumFileSize int, AllowedFileExtensions []string, Compression string, Encryption bool, Antivirus bool) (*Bucket, error) {
+	return srv.UpdateBucketContext(context.Background(), bucketId, Name, Permissions, FileSecurity, Enabled, MaximumFileSize, AllowedFileExtensions, Compression, Encryption, Antivirus)
+}
+
+// UpdateBucketContext is like UpdateBucket but cancels the request when ctx is done.
+func (srv *Storage) UpdateBucketContext(ctx context.Context, bucketId, Name string, Permissions []string, FileSecurity bool, Enabled bool, MaximumFileSize int, AllowedFileExtensions []string, Compression string, Encryption bool, Antivirus bool) (*Bucket, error) {
+	r := pathReplacer("{bucketId}", bucketId)
+	path := r.Replace("/storage/buckets/{bucketId}")
+
+	params := map[string]interface{}{
+		"name":                  Name,
+		"permissions":           Permissions,
+		"fileSecurity":          FileSecurity,
+		"enabled":               Enabled,
+		"maximumFileSize":       MaximumFileSize,
+		"allowedFileExtensions": AllowedFileExtensions,
+		"compression":           Compression,
+		"encryption":            Encryption,
+		"antivirus":             Antivirus,
+	}
+
+	resp, err := srv.Client.CallAPIContext(ctx, "PUT", path, srv.Client.headers, params)
+	if err != nil {
+		return nil, err
+	}
+	var result Bucket
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DeleteBucket delete a storage bucket by its unique ID.
+func (srv *Storage) DeleteBucket(bucketId string) error {
+	return srv.DeleteBucketContext(context.Background(), bucketId)
+}
+
+// DeleteBucketContext is like DeleteBucket but cancels the request when ctx is done.
+func (srv *Storage) DeleteBucketContext(ctx context.Context, bucketId string) error {
+	r := pathReplacer("{bucketId}", bucketId)
+	path := r.Replace("/storage/buckets/{bucketId}")
+
+	params := map[string]interface{}{}
+
+	_, err := srv.Client.CallAPIContext(ctx, "DELETE", path, srv.Client.headers, params)
+	return err
+}
+
 // ListFiles get a list of all the user files. You can use the query params to
 // filter your results. On admin mode, this endpoint will return a list of all
 // of the project files. [Learn more about different API modes](/docs/admin).
 func (srv *Storage) ListFiles(bucketId, Search string, Limit int, Offset int, OrderType string) (*FileListResponse, error) {
-	r := strings.NewReplacer("{bucketId}", bucketId)
+	return srv.ListFilesContext(context.Background(), bucketId, Search, Limit, Offset, OrderType)
+}
+
+// ListFilesContext is like ListFiles but cancels the request when ctx is done.
+func (srv *Storage) ListFilesContext(ctx context.Context, bucketId, Search string, Limit int, Offset int, OrderType string) (*FileListResponse, error) {
+	r := pathReplacer("{bucketId}", bucketId)
 	path := r.Replace("/storage/buckets/{bucketId}/files")
 
 	params := map[string]interface{}{
@@ -112,7 +219,7 @@ func (srv *Storage) ListFiles(bucketId, Search string, Limit int, Offset int, Or
 		"offset":    Offset,
 		"orderType": OrderType,
 	}
-	resp, err := srv.Client.CallAPI("GET", path, srv.Client.headers, params)
+	resp, err := srv.Client.CallAPIContext(ctx, "GET", path, srv.Client.headers, params)
 	if err != nil {
 		return nil, err
 	}
@@ -123,30 +230,100 @@ func (srv *Storage) ListFiles(bucketId, Search string, Limit int, Offset int, Or
 	return &result, nil
 }
 
-// CreateFile create a new file. The user who creates the file will
-// automatically be assigned to read and write access unless he has passed
-// custom values for read and write arguments.
-func (srv *Storage) CreateFile(File string, Read []interface{}, Write []interface{}) (map[string]interface{}, error) {
-	path := "/storage/files"
+// CreateFile upload a new file to the bucket. Files larger than ChunkSize
+// are uploaded in chunks, and if a partial upload with the same fileId
+// already exists the upload resumes after the chunks the server already
+// has, while a complete one fails with ErrConflict. When the size of file
+// can't be determined through io.Seeker or a Len method, it is read into
+// memory first.
+func (srv *Storage) CreateFile(bucketId, fileId string, file io.Reader, name string, permissions []string) (*File, error) {
+	return srv.CreateFileContext(context.Background(), bucketId, fileId, file, name, permissions)
+}
 
-	params := map[string]interface{}{
-		"file":  File,
-		"read":  Read,
-		"write": Write,
+// CreateFileContext is like CreateFile but cancels the request when ctx is done.
+func (srv *Storage) CreateFileContext(ctx context.Context, bucketId, fileId string, file io.Reader, name string, permissions []string) (*File, error) {
+	if err := validateId("fileId", fileId); err != nil {
+		return nil, err
 	}
+	r := pathReplacer("{bucketId}", bucketId)
+	path := r.Replace("/storage/buckets/{bucketId}/files")
 
-	return srv.Client.Call("POST", path, nil, params)
+	size, err := readerSize(file)
+	if err != nil {
+		return nil, err
+	}
+	if size < 0 {
+		data, err := ioutil.ReadAll(file)
+		if err != nil {
+			return nil, err
+		}
+		file = bytes.NewReader(data)
+		size = int64(len(data))
+	}
+
+	headers := map[string]interface{}{}
+	if size <= ChunkSize {
+		data, err := ioutil.ReadAll(io.LimitReader(file, size))
+		if err != nil {
+			return nil, err
+		}
+		return srv.uploadChunk(ctx, path, headers, fileId, name, permissions, data)
+	}
+
+	var offset int64
+	if fileId != id.Unique() {
+		existing, err := srv.GetFileContext(ctx, bucketId, fileId)
+		switch {
+		case err == nil && existing.ChunksUploaded < existing.ChunksTotal:
+			// Resume an interrupted upload
+			offset = int64(existing.ChunksUploaded) * ChunkSize
+			if err := skipBytes(file, offset); err != nil {
+				return nil, err
+			}
+			headers["x-appwrite-id"] = fileId
+		case err == nil:
+			return nil, fmt.Errorf("appwrite: file %s already exists: %w", fileId, ErrConflict)
+		case !errors.Is(err, ErrNotFound):
+			return nil, err
+		}
+	}
+
+	var result *File
+	chunk := make([]byte, ChunkSize)
+	for offset < size {
+		length := ChunkSize
+		if size-offset < ChunkSize {
+			length = int(size - offset)
+		}
+		if _, err := io.ReadFull(file, chunk[:length]); err != nil {
+			return nil, err
+		}
+
+		headers["Content-Range"] = chunkRange(offset, length, size)
+		result, err = srv.uploadChunk(ctx, path, headers, fileId, name, permissions, chunk[:length])
+		if err != nil {
+			return nil, err
+		}
+		headers["x-appwrite-id"] = result.Id
+		offset += int64(length)
+	}
+	return result, nil
 }
 
 // GetFile get file by its unique ID. This endpoint response returns a JSON
 // object with the file metadata.
 func (srv *Storage) GetFile(bucketId, fileId string) (*File, error) {
-	r := strings.NewReplacer("{bucketId}", bucketId, "{fileId}", fileId)
+	return srv.GetFileContext(context.Background(), bucketId, fileId)
+}
+
+// GetFileContext is like GetFile but cancels the request when ctx is done.
+func (srv *Storage) GetFileContext(ctx context.Context, bucketId, fileId string) (*File, error) {
+	r := pathReplacer("{bucketId}", bucketId, "{fileId}", fileId)
 	path := r.Replace("/storage/buckets/{bucketId}/files/{fileId}")
 
 	params := map[string]interface{}{}
 
-	resp, err := srv.Client.CallAPI("GET", path, srv.Client.headers, params)
+	resp, err := srv.Client.CallAPIContext(ctx, "GET", path, srv.Client.headers, params)
 	if err != nil {
 		return nil, err
 	}
@@ -160,7 +337,12 @@ func (srv *Storage) GetFile(bucketId, fileId string) (*File, error) {
 // UpdateFile update file by its unique ID. Only users with write permissions
 // have access to update this resource.
 func (srv *Storage) UpdateFile(FileId string, Read []interface{}, Write []interface{}) (map[string]interface{}, error) {
-	r := strings.NewReplacer("{fileId}", FileId)
+	return srv.UpdateFileContext(context.Background(), FileId, Read, Write)
+}
+
+// UpdateFileContext is like UpdateFile but cancels the request when ctx is done.
+func (srv *Storage) UpdateFileContext(ctx context.Context, FileId string, Read []interface{}, Write []interface{}) (map[string]interface{}, error) {
+	r := pathReplacer("{fileId}", FileId)
 	path := r.Replace("/storage/files/{fileId}")
 
 	params := map[string]interface{}{
@@ -168,30 +350,70 @@ func (srv *Storage) UpdateFile(FileId string, Read []interface{}, Write []interf
 		"write": Write,
 	}
 
-	return srv.Client.Call("PUT", path, nil, params)
+	return srv.Client.CallContext(ctx, "PUT", path, nil, params)
 }
 
 // DeleteFile delete a file by its unique ID. Only users with write
 // permissions have access to delete this resource.
 func (srv *Storage) DeleteFile(FileId string) (map[string]interface{}, error) {
-	r := strings.NewReplacer("{fileId}", FileId)
+	return srv.DeleteFileContext(context.Background(), FileId)
+}
+
+// DeleteFileContext is like DeleteFile but cancels the request when ctx is done.
+func (srv *Storage) DeleteFileContext(ctx context.Context, FileId string) (map[string]interface{}, error) {
+	r := pathReplacer("{fileId}", FileId)
 	path := r.Replace("/storage/files/{fileId}")
 
 	params := map[string]interface{}{}
 
-	return srv.Client.Call("DELETE", path, nil, params)
+	return srv.Client.CallContext(ctx, "DELETE", path, nil, params)
 }
 
 // GetFileDownload get file content by its unique ID. The endpoint response
 // return with a 'Content-Disposition: attachment' header that tells the
-// browser to start downloading the file to user downloads directory.
-func (srv *Storage) GetFileDownload(FileId string) (map[string]interface{}, error) {
-	r := strings.NewReplacer("{fileId}", FileId)
-	path := r.Replace("/storage/files/{fileId}/download")
+// browser to start downloading the file to user downloads directory. The
+// content is streamed and must be closed once read.
+func (srv *Storage) GetFileDownload(bucketId, fileId string) (*FileContent, error) {
+	return srv.GetFileDownloadContext(context.Background(), bucketId, fileId)
+}
 
-	params := map[string]interface{}{}
+// GetFileDownloadContext is like GetFileDownload but cancels the request when ctx is done.
+func (srv *Storage) GetFileDownloadContext(ctx context.Context, bucketId, fileId string) (*FileContent, error) {
+	return srv.fetchFile(ctx, "/storage/buckets/{bucketId}/files/{fileId}/download", bucketId, fileId, -1, -1)
+}
+
+// GetFileDownloadRange get length bytes of the file content starting at
+// offset, using an HTTP Range request. length must be positive.
+func (srv *Storage) GetFileDownloadRange(bucketId, fileId string, offset int64, length int64) (*FileContent, error) {
+	return srv.GetFileDownloadRangeContext(context.Background(), bucketId, fileId, offset, length)
+}
+
+// GetFileDownloadRangeContext is like GetFileDownloadRange but cancels the request when ctx is done.
+func (srv *Storage) GetFileDownloadRangeContext(ctx context.Context, bucketId, fileId string, offset int64, length int64) (*FileContent, error) {
+	if offset < 0 {
+		return nil, fmt.Errorf("appwrite: invalid range offset %d", offset)
+	}
+	if length <= 0 {
+		return nil, fmt.Errorf("appwrite: invalid range length %d", length)
+	}
+	return srv.fetchFile(ctx, "/storage/buckets/{bucketId}/files/{fileId}/download", bucketId, fileId, offset, length)
+}
 
-	return srv.Client.Call("GET", path, nil, params)
+// DownloadFile write the file content to w without buffering the whole file
+// and return the number of bytes written.
+func (srv *Storage) DownloadFile(bucketId, fileId string, w io.Writer) (int64, error) {
+	return srv.DownloadFileContext(context.Background(), bucketId, fileId, w)
+}
+
+// DownloadFileContext is like DownloadFile but cancels the request when ctx is done.
+func (srv *Storage) DownloadFileContext(ctx context.Context, bucketId, fileId string, w io.Writer) (int64, error) {
+	content, err := srv.GetFileDownloadContext(ctx, bucketId, fileId)
+	if err != nil {
+		return 0, err
+	}
+	defer content.Close()
+
+	return io.Copy(w, content)
 }
 
 // GetFilePreview get a file preview image. Currently, this method supports
@@ -200,7 +422,12 @@ func (srv *Storage) GetFileDownload(FileId string) (map[string]interface{}, erro
 // can also pass query string arguments for cutting and resizing your preview
 // image.
 func (srv *Storage) GetFilePreview(FileId string, Width int, Height int, Quality int, Background string, Output string) (map[string]interface{}, error) {
-	r := strings.NewReplacer("{fileId}", FileId)
+	return srv.GetFilePreviewContext(context.Background(), FileId, Width, Height, Quality, Background, Output)
+}
+
+// GetFilePreviewContext is like GetFilePreview but cancels the request when ctx is done.
+func (srv *Storage) GetFilePreviewContext(ctx context.Context, FileId string, Width int, Height int, Quality int, Background string, Output string) (map[string]interface{}, error) {
+	r := pathReplacer("{fileId}", FileId)
 	path := r.Replace("/storage/files/{fileId}/preview")
 
 	params := map[string]interface{}{
@@ -211,19 +438,17 @@ func (srv *Storage) GetFilePreview(FileId string, Width int, Height int, Quality
 		"output":     Output,
 	}
 
-	return srv.Client.Call("GET", path, nil, params)
+	return srv.Client.CallContext(ctx, "GET", path, nil, params)
 }
 
 // GetFileView get file content by its unique ID. This endpoint is similar to
 // the download method but returns with no  'Content-Disposition: attachment'
-// header.
-func (srv *Storage) GetFileView(FileId string, As string) (map[string]interface{}, error) {
-	r := strings.NewReplacer("{fileId}", FileId)
-	path := r.Replace("/storage/files/{fileId}/view")
-
-	params := map[string]interface{}{
-		"as": As,
-	}
+// header. The content is streamed and must be closed once read.
+func (srv *Storage) GetFileView(bucketId, fileId string) (*FileContent, error) {
+	return srv.GetFileViewContext(context.Background(), bucketId, fileId)
+}
 
-	return srv.Client.Call("GET", path, nil, params)
+// GetFileViewContext is like GetFileView but cancels the request when ctx is done.
+func (srv *Storage) GetFileViewContext(ctx context.Context, bucketId, fileId string) (*FileContent, error) {
+	return srv.fetchFile(ctx, "/storage/buckets/{bucketId}/files/{fileId}/view", bucketId, fileId, -1, -1)
 }
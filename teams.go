@@ -1,20 +1,32 @@
 package appwrite
 
-import (
-	"strings"
-)
+import "context"
 
 // Teams service
 type Teams struct {
 	Client Client
 }
 
-func NewTeams(clt Client) Teams {  
-    service := Teams{
+type TeamObject struct {
+	Id        string                 `json:"$id"`
+	Name      string                 `json:"name"`
+	CreatedAt string                 `json:"$createdAt"`
+	UpdatedAt string                 `json:"$updatedAt"`
+	Total     int                    `json:"total"`
+	Prefs     map[string]interface{} `json:"prefs"`
+}
+
+type TeamListResponse struct {
+	Total int          `json:"total"`
+	Teams []TeamObject `json:"teams"`
+}
+
+func NewTeams(clt Client) Teams {
+	service := Teams{
 		Client: clt,
 	}
 
-    return service
+	return service
 }
 
 // List get a list of all the current user teams. You can use the query params
@@ -22,16 +34,21 @@ func NewTeams(clt Client) Teams {
 // all of the project teams. [Learn more about different API
 // modes](/docs/admin).
 func (srv *Teams) List(Search string, Limit int, Offset int, OrderType string) (map[string]interface{}, error) {
+	return srv.ListContext(context.Background(), Search, Limit, Offset, OrderType)
+}
+
+// ListContext is like List but cancels the request when ctx is done.
+func (srv *Teams) ListContext(ctx context.Context, Search string, Limit int, Offset int, OrderType string) (map[string]interface{}, error) {
 	path := "/teams"
 
 	params := map[string]interface{}{
-		"search": Search,
-		"limit": Limit,
-		"offset": Offset,
+		"search":    Search,
+		"limit":     Limit,
+		"offset":    Offset,
 		"orderType": OrderType,
 	}
 
-	return srv.Client.Call("GET", path, nil, params)
+	return srv.Client.CallContext(ctx, "GET", path, nil, params)
 }
 
 // Create create a new team. The user who creates the team will automatically
@@ -39,102 +56,133 @@ func (srv *Teams) List(Search string, Limit int, Offset int, OrderType string) (
 // members, who will be able add new owners and update or delete the team from
 // your project.
 func (srv *Teams) Create(Name string, Roles []interface{}) (map[string]interface{}, error) {
+	return srv.CreateContext(context.Background(), Name, Roles)
+}
+
+// CreateContext is like Create but cancels the request when ctx is done.
+func (srv *Teams) CreateContext(ctx context.Context, Name string, Roles []interface{}) (map[string]interface{}, error) {
 	path := "/teams"
 
 	params := map[string]interface{}{
-		"name": Name,
+		"name":  Name,
 		"roles": Roles,
 	}
 
-	return srv.Client.Call("POST", path, nil, params)
+	return srv.Client.CallContext(ctx, "POST", path, nil, params)
 }
 
 // Get get team by its unique ID. All team members have read access for this
 // resource.
 func (srv *Teams) Get(TeamId string) (map[string]interface{}, error) {
-	r := strings.NewReplacer("{teamId}", TeamId)
+	return srv.GetContext(context.Background(), TeamId)
+}
+
+// GetContext is like Get but cancels the request when ctx is done.
+func (srv *Teams) GetContext(ctx context.Context, TeamId string) (map[string]interface{}, error) {
+	r := pathReplacer("{teamId}", TeamId)
 	path := r.Replace("/teams/{teamId}")
 
-	params := map[string]interface{}{
-	}
+	params := map[string]interface{}{}
 
-	return srv.Client.Call("GET", path, nil, params)
+	return srv.Client.CallContext(ctx, "GET", path, nil, params)
 }
 
 // Update update team by its unique ID. Only team owners have write access for
 // this resource.
 func (srv *Teams) Update(TeamId string, Name string) (map[string]interface{}, error) {
-	r := strings.NewReplacer("{teamId}", TeamId)
+	return srv.UpdateContext(context.Background(), TeamId, Name)
+}
+
+// UpdateContext is like Update but cancels the request when ctx is done.
+func (srv *Teams) UpdateContext(ctx context.Context, TeamId string, Name string) (map[string]interface{}, error) {
+	r := pathReplacer("{teamId}", TeamId)
 	path := r.Replace("/teams/{teamId}")
 
 	params := map[string]interface{}{
 		"name": Name,
 	}
 
-	return srv.Client.Call("PUT", path, nil, params)
+	return srv.Client.CallContext(ctx, "PUT", path, nil, params)
 }
 
 // Delete delete team by its unique ID. Only team owners have write access for
 // this resource.
 func (srv *Teams) Delete(TeamId string) (map[string]interface{}, error) {
-	r := strings.NewReplacer("{teamId}", TeamId)
+	return srv.DeleteContext(context.Background(), TeamId)
+}
+
+// DeleteContext is like Delete but cancels the request when ctx is done.
+func (srv *Teams) DeleteContext(ctx context.Context, TeamId string) (map[string]interface{}, error) {
+	r := pathReplacer("{teamId}", TeamId)
 	path := r.Replace("/teams/{teamId}")
 
-	params := map[string]interface{}{
-	}
+	params := map[string]interface{}{}
 
-	return srv.Client.Call("DELETE", path, nil, params)
+	return srv.Client.CallContext(ctx, "DELETE", path, nil, params)
 }
 
 // GetMemberships get team members by the team unique ID. All team members
 // have read access for this list of resources.
 func (srv *Teams) GetMemberships(TeamId string) (map[string]interface{}, error) {
-	r := strings.NewReplacer("{teamId}", TeamId)
+	return srv.GetMembershipsContext(context.Background(), TeamId)
+}
+
+// GetMembershipsContext is like GetMemberships but cancels the request when ctx is done.
+func (srv *Teams) GetMembershipsContext(ctx context.Context, TeamId string) (map[string]interface{}, error) {
+	r := pathReplacer("{teamId}", TeamId)
 	path := r.Replace("/teams/{teamId}/memberships")
 
-	params := map[string]interface{}{
-	}
+	params := map[string]interface{}{}
 
-	return srv.Client.Call("GET", path, nil, params)
+	return srv.Client.CallContext(ctx, "GET", path, nil, params)
 }
 
 // CreateMembership use this endpoint to invite a new member to join your
 // team. An email with a link to join the team will be sent to the new member
 // email address if the member doesn't exist in the project it will be created
 // automatically.
-// 
+//
 // Use the 'URL' parameter to redirect the user from the invitation email back
 // to your app. When the user is redirected, use the [Update Team Membership
 // Status](/docs/teams#updateMembershipStatus) endpoint to allow the user to
 // accept the invitation to the team.
-// 
+//
 // Please note that in order to avoid a [Redirect
 // Attacks](https://github.com/OWASP/CheatSheetSeries/blob/master/cheatsheets/Unvalidated_Redirects_and_Forwards_Cheat_Sheet.md)
 // the only valid redirect URL's are the once from domains you have set when
 // added your platforms in the console interface.
 func (srv *Teams) CreateMembership(TeamId string, Email string, Roles []interface{}, Url string, Name string) (map[string]interface{}, error) {
-	r := strings.NewReplacer("{teamId}", TeamId)
+	return srv.CreateMembershipContext(context.Background(), TeamId, Email, Roles, Url, Name)
+}
+
+// CreateMembershipContext is like CreateMembership but cancels the request when ctx is done.
+func (srv *Teams) CreateMembershipContext(ctx context.Context, TeamId string, Email string, Roles []interface{}, Url string, Name string) (map[string]interface{}, error) {
+	r := pathReplacer("{teamId}", TeamId)
 	path := r.Replace("/teams/{teamId}/memberships")
 
 	params := map[string]interface{}{
 		"email": Email,
-		"name": Name,
+		"name":  Name,
 		"roles": Roles,
-		"url": Url,
+		"url":   Url,
 	}
 
-	return srv.Client.Call("POST", path, nil, params)
+	return srv.Client.CallContext(ctx, "POST", path, nil, params)
 }
 
 // DeleteMembership this endpoint allows a user to leave a team or for a team
 // owner to delete the membership of any other team member. You can also use
 // this endpoint to delete a user membership even if he didn't accept it.
 func (srv *Teams) DeleteMembership(TeamId string, InviteId string) (map[string]interface{}, error) {
-	r := strings.NewReplacer("{teamId}", TeamId, "{inviteId}", InviteId)
+	return srv.DeleteMembershipContext(context.Background(), TeamId, InviteId)
+}
+
+// DeleteMembershipContext is like DeleteMembership but cancels the request when ctx is done.
+func (srv *Teams) DeleteMembershipContext(ctx context.Context, TeamId string, InviteId string) (map[string]interface{}, error) {
+	r := pathReplacer("{teamId}", TeamId, "{inviteId}", InviteId)
 	path := r.Replace("/teams/{teamId}/memberships/{inviteId}")
 
-	params := map[string]interface{}{
-	}
+	params := map[string]interface{}{}
 
-	return srv.Client.Call("DELETE", path, nil, params)
+	return srv.Client.CallContext(ctx, "DELETE", path, nil, params)
 }
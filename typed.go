@@ -0,0 +1,270 @@
+package appwrite
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// DocumentMeta holds the system fields of a document. Embed it in a struct
+// used with GetDocumentAs, ListDocumentsAs or CreateDocumentFrom to have
+// them filled in.
+type DocumentMeta struct {
+	Id           string   `json:"$id"`
+	CreatedAt    string   `json:"$createdAt"`
+	UpdatedAt    string   `json:"$updatedAt"`
+	Permissions  []string `json:"$permissions"`
+	CollectionId string   `json:"$collectionId"`
+	DatabaseId   string   `json:"$databaseId"`
+}
+
+// TypedDocumentList is a page of documents decoded into T
+type TypedDocumentList[T any] struct {
+	Total     int
+	Documents []T
+}
+
+// GetDocumentAs get a document and decode it into a T. Struct fields are
+// mapped to attributes through their appwrite tag, falling back to their json
+// tag and then their name.
+func GetDocumentAs[T any](srv *Database, databaseId, collectionId, documentId string) (*T, error) {
+	return GetDocumentAsContext[T](context.Background(), srv, databaseId, collectionId, documentId)
+}
+
+// GetDocumentAsContext is like GetDocumentAs but cancels the request when ctx is done.
+func GetDocumentAsContext[T any](ctx context.Context, srv *Database, databaseId, collectionId, documentId string) (*T, error) {
+	r := pathReplacer("{databaseId}", databaseId, "{collectionId}", collectionId, "{documentId}", documentId)
+	path := r.Replace("/databases/{databaseId}/collections/{collectionId}/documents/{documentId}")
+
+	resp, err := srv.Client.CallAPIContext(ctx, "GET", path, srv.Client.headers, nil)
+	if err != nil {
+		return nil, err
+	}
+	var result T
+	if err := decodeStruct(resp, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ListDocumentsAs list documents and decode each of them into a T.
+func ListDocumentsAs[T any](srv *Database, databaseId, collectionId string, Queries []string) (*TypedDocumentList[T], error) {
+	return ListDocumentsAsContext[T](context.Background(), srv, databaseId, collectionId, Queries)
+}
+
+// ListDocumentsAsContext is like ListDocumentsAs but cancels the request when ctx is done.
+func ListDocumentsAsContext[T any](ctx context.Context, srv *Database, databaseId, collectionId string, Queries []string) (*TypedDocumentList[T], error) {
+	r := pathReplacer("{databaseId}", databaseId, "{collectionId}", collectionId)
+	path := r.Replace("/databases/{databaseId}/collections/{collectionId}/documents")
+
+	params := map[string]interface{}{
+		"queries": Queries,
+	}
+
+	resp, err := srv.Client.CallAPIContext(ctx, "GET", path, srv.Client.headers, params)
+	if err != nil {
+		return nil, err
+	}
+	var page struct {
+		Total     int               `json:"total"`
+		Documents []json.RawMessage `json:"documents"`
+	}
+	if err := json.Unmarshal(resp, &page); err != nil {
+		return nil, err
+	}
+
+	result := &TypedDocumentList[T]{
+		Total:     page.Total,
+		Documents: make([]T, len(page.Documents)),
+	}
+	for i, raw := range page.Documents {
+		if err := decodeStruct(raw, &result.Documents[i]); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// CreateDocumentFrom create a document out of the attributes of value and
+// decode the created document back into a T. System fields of value, such
+// as the ones of an embedded DocumentMeta, are not sent.
+func CreateDocumentFrom[T any](srv *Database, databaseId, collectionId, documentId string, value T, Permissions []string) (*T, error) {
+	return CreateDocumentFromContext(context.Background(), srv, databaseId, collectionId, documentId, value, Permissions)
+}
+
+// CreateDocumentFromContext is like CreateDocumentFrom but cancels the request when ctx is done.
+func CreateDocumentFromContext[T any](ctx context.Context, srv *Database, databaseId, collectionId, documentId string, value T, Permissions []string) (*T, error) {
+	if err := validateId("documentId", documentId); err != nil {
+		return nil, err
+	}
+	data, err := encodeStruct(value)
+	if err != nil {
+		return nil, err
+	}
+
+	r := pathReplacer("{databaseId}", databaseId, "{collectionId}", collectionId)
+	path := r.Replace("/databases/{databaseId}/collections/{collectionId}/documents")
+
+	params := map[string]interface{}{
+		"documentId":  documentId,
+		"data":        data,
+		"permissions": Permissions,
+	}
+
+	resp, err := srv.Client.CallAPIContext(ctx, "POST", path, srv.Client.headers, params)
+	if err != nil {
+		return nil, err
+	}
+	var result T
+	if err := decodeStruct(resp, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// UpdateDocumentFrom update a document with the attributes of value and
+// decode the updated document back into a T. Permissions is left untouched
+// when nil.
+func UpdateDocumentFrom[T any](srv *Database, databaseId, collectionId, documentId string, value T, Permissions []string) (*T, error) {
+	return UpdateDocumentFromContext(context.Background(), srv, databaseId, collectionId, documentId, value, Permissions)
+}
+
+// UpdateDocumentFromContext is like UpdateDocumentFrom but cancels the request when ctx is done.
+func UpdateDocumentFromContext[T any](ctx context.Context, srv *Database, databaseId, collectionId, documentId string, value T, Permissions []string) (*T, error) {
+	data, err := encodeStruct(value)
+	if err != nil {
+		return nil, err
+	}
+
+	r := pathReplacer("{databaseId}", databaseId, "{collectionId}", collectionId, "{documentId}", documentId)
+	path := r.Replace("/databases/{databaseId}/collections/{collectionId}/documents/{documentId}")
+
+	params := map[string]interface{}{
+		"data": data,
+	}
+	if Permissions != nil {
+		params["permissions"] = Permissions
+	}
+
+	resp, err := srv.Client.CallAPIContext(ctx, "PATCH", path, srv.Client.headers, params)
+	if err != nil {
+		return nil, err
+	}
+	var result T
+	if err := decodeStruct(resp, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// structField is a struct field mapped to a document attribute
+type structField struct {
+	key       string
+	omitEmpty bool
+	value     reflect.Value
+}
+
+// fieldTag returns the attribute key of a field and its options, reading the
+// appwrite tag first and the json tag second
+func fieldTag(f reflect.StructField) (string, string, bool) {
+	tag, ok := f.Tag.Lookup("appwrite")
+	if !ok {
+		tag, ok = f.Tag.Lookup("json")
+	}
+	name, opts, _ := strings.Cut(tag, ",")
+	return name, opts, ok
+}
+
+// structFields lists the fields of the struct v mapped to attributes,
+// flattening untagged embedded structs such as DocumentMeta
+func structFields(v reflect.Value) []structField {
+	var fields []structField
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, opts, tagged := fieldTag(f)
+		if name == "-" && opts == "" {
+			continue
+		}
+
+		fv := v.Field(i)
+		if f.Anonymous && !tagged {
+			if fv.Kind() == reflect.Ptr {
+				if fv.IsNil() {
+					if !fv.CanSet() {
+						continue
+					}
+					fv.Set(reflect.New(fv.Type().Elem()))
+				}
+				fv = fv.Elem()
+			}
+			if fv.Kind() == reflect.Struct {
+				fields = append(fields, structFields(fv)...)
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = f.Name
+		}
+		fields = append(fields, structField{
+			key:       name,
+			omitEmpty: strings.Contains(","+opts+",", ",omitempty,"),
+			value:     fv,
+		})
+	}
+	return fields
+}
+
+// decodeStruct decodes a document into the struct pointed to by out
+func decodeStruct(data []byte, out interface{}) error {
+	v := reflect.ValueOf(out).Elem()
+	if v.Kind() != reflect.Struct {
+		return json.Unmarshal(data, out)
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	for _, field := range structFields(v) {
+		val, ok := raw[field.key]
+		if !ok {
+			continue
+		}
+		if err := json.Unmarshal(val, field.value.Addr().Interface()); err != nil {
+			return fmt.Errorf("appwrite: decoding attribute %q: %w", field.key, err)
+		}
+	}
+	return nil
+}
+
+// encodeStruct turns a struct into the data of a document, leaving out
+// $-prefixed system fields
+func encodeStruct(value interface{}) (map[string]interface{}, error) {
+	v := reflect.Indirect(reflect.ValueOf(value))
+	if v.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("appwrite: cannot encode %T as document data", value)
+	}
+
+	// Work on a copy so that embedded nil pointers can be allocated
+	copied := reflect.New(v.Type()).Elem()
+	copied.Set(v)
+
+	data := make(map[string]interface{})
+	for _, field := range structFields(copied) {
+		if strings.HasPrefix(field.key, "$") {
+			continue
+		}
+		if field.omitEmpty && field.value.IsZero() {
+			continue
+		}
+		data[field.key] = field.value.Interface()
+	}
+	return data, nil
+}
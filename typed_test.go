@@ -0,0 +1,146 @@
+package appwrite
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+// tagged exercises the struct tags mapping fields to attributes
+type tagged struct {
+	DocumentMeta
+	Title    string   `appwrite:"title" json:"headline"`
+	Body     string   `json:"body"`
+	Views    int      `json:"views,omitempty"`
+	Tags     []string `appwrite:"tags,omitempty"`
+	Skipped  string   `appwrite:"-" json:"skipped"`
+	Untagged bool
+	internal string
+}
+
+// taggedPtr embeds DocumentMeta through a pointer
+type taggedPtr struct {
+	*DocumentMeta
+	Title string `json:"title"`
+}
+
+func TestEncodeStruct(t *testing.T) {
+	tests := []struct {
+		name  string
+		value interface{}
+		want  map[string]interface{}
+	}{
+		{"tags", tagged{
+			DocumentMeta: DocumentMeta{Id: "d1", Permissions: []string{`read("any")`}},
+			Title:        "Hello", Body: "World", Views: 3, Tags: []string{"a"},
+			Skipped: "no", Untagged: true, internal: "no",
+		}, map[string]interface{}{
+			"title": "Hello", "body": "World", "views": 3, "tags": []string{"a"}, "Untagged": true,
+		}},
+		{"omitempty", tagged{Title: "Hello"}, map[string]interface{}{
+			"title": "Hello", "body": "", "Untagged": false,
+		}},
+		{"pointer", &tagged{Title: "Hello"}, map[string]interface{}{
+			"title": "Hello", "body": "", "Untagged": false,
+		}},
+		{"nil embedded pointer", taggedPtr{Title: "Hello"}, map[string]interface{}{"title": "Hello"}},
+		{"embedded pointer", taggedPtr{DocumentMeta: &DocumentMeta{Id: "d1"}, Title: "Hello"}, map[string]interface{}{"title": "Hello"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := encodeStruct(tt.value)
+			if err != nil {
+				t.Fatalf("encodeStruct: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got  %#v\nwant %#v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := encodeStruct(map[string]interface{}{}); err == nil {
+		t.Error("encodeStruct of a map succeeded, want an error")
+	}
+}
+
+const taggedDocument = `{
+	"$id": "d1",
+	"$createdAt": "2024-01-01T00:00:00.000+00:00",
+	"$permissions": ["read(\"any\")"],
+	"$collectionId": "posts",
+	"$databaseId": "main",
+	"title": "Hello",
+	"headline": "ignored",
+	"body": "World",
+	"views": 3,
+	"tags": ["a", "b"],
+	"Skipped": "ignored",
+	"skipped": "ignored",
+	"Untagged": true
+}`
+
+func TestDecodeStruct(t *testing.T) {
+	var got tagged
+	if err := decodeStruct([]byte(taggedDocument), &got); err != nil {
+		t.Fatalf("decodeStruct: %v", err)
+	}
+	want := tagged{
+		DocumentMeta: DocumentMeta{
+			Id:           "d1",
+			CreatedAt:    "2024-01-01T00:00:00.000+00:00",
+			Permissions:  []string{`read("any")`},
+			CollectionId: "posts",
+			DatabaseId:   "main",
+		},
+		Title: "Hello", Body: "World", Views: 3, Tags: []string{"a", "b"}, Untagged: true,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got  %+v\nwant %+v", got, want)
+	}
+
+	// A nil embedded pointer is allocated to receive the system fields
+	var ptr taggedPtr
+	if err := decodeStruct([]byte(taggedDocument), &ptr); err != nil {
+		t.Fatalf("decodeStruct: %v", err)
+	}
+	if ptr.DocumentMeta == nil || ptr.Id != "d1" || ptr.Title != "Hello" {
+		t.Errorf("got %+v with meta %+v", ptr, ptr.DocumentMeta)
+	}
+
+	if err := decodeStruct([]byte(`{"views":"many"}`), &got); err == nil {
+		t.Error("decoding a string into an int succeeded, want an error")
+	}
+}
+
+func TestListDocumentsAs(t *testing.T) {
+	var queries []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = r.URL.Query()["queries[]"]
+		fmt.Fprintf(w, `{"total":5,"documents":[%s,{"$id":"d2","title":"Second"}]}`, taggedDocument)
+	}))
+	defer srv.Close()
+
+	client := NewClient()
+	client.SetEndpoint(srv.URL)
+	db := NewDatabase(client)
+
+	list, err := ListDocumentsAs[tagged](&db, "main", "posts", []string{`limit(2)`})
+	if err != nil {
+		t.Fatalf("ListDocumentsAs: %v", err)
+	}
+	if list.Total != 5 || len(list.Documents) != 2 {
+		t.Fatalf("got %d documents of %d, want 2 of 5", len(list.Documents), list.Total)
+	}
+	if doc := list.Documents[0]; doc.Id != "d1" || doc.Title != "Hello" || !reflect.DeepEqual(doc.Tags, []string{"a", "b"}) {
+		t.Errorf("first document = %+v", doc)
+	}
+	if doc := list.Documents[1]; doc.Id != "d2" || doc.Title != "Second" || doc.Tags != nil {
+		t.Errorf("second document = %+v", doc)
+	}
+	if !reflect.DeepEqual(queries, []string{`limit(2)`}) {
+		t.Errorf("queries = %q, want [limit(2)]", queries)
+	}
+}
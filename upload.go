@@ -0,0 +1,89 @@
+package appwrite
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+)
+
+// ChunkSize is the size of the chunks files larger than it are split into
+// when uploaded through Storage.CreateFile
+const ChunkSize = 5 * 1024 * 1024
+
+// readerSize returns the number of bytes left in file, or -1 when it can't
+// be known without reading it, as with pipes and sockets that implement
+// io.Seeker but can't seek
+func readerSize(file io.Reader) (int64, error) {
+	if v, ok := file.(io.Seeker); ok {
+		cur, err := v.Seek(0, io.SeekCurrent)
+		if err == nil {
+			end, err := v.Seek(0, io.SeekEnd)
+			if err != nil {
+				return -1, nil
+			}
+			if _, err := v.Seek(cur, io.SeekStart); err != nil {
+				return 0, err
+			}
+			return end - cur, nil
+		}
+	}
+	if v, ok := file.(interface{ Len() int }); ok {
+		return int64(v.Len()), nil
+	}
+	return -1, nil
+}
+
+// skipBytes discards the next n bytes of file
+func skipBytes(file io.Reader, n int64) error {
+	if seeker, ok := file.(io.Seeker); ok {
+		_, err := seeker.Seek(n, io.SeekCurrent)
+		return err
+	}
+	_, err := io.CopyN(ioutil.Discard, file, n)
+	return err
+}
+
+// uploadChunk posts a single multipart/form-data chunk of a file
+func (srv *Storage) uploadChunk(ctx context.Context, path string, headers map[string]interface{}, fileId string, name string, permissions []string, chunk []byte) (*File, error) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	if err := writer.WriteField("fileId", fileId); err != nil {
+		return nil, err
+	}
+	for _, permission := range permissions {
+		if err := writer.WriteField("permissions[]", permission); err != nil {
+			return nil, err
+		}
+	}
+	part, err := writer.CreateFormFile("file", name)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := part.Write(chunk); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	response, err := srv.Client.send(ctx, "POST", path, headers, nil, body.Bytes(), writer.FormDataContentType())
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	var result File
+	if err := json.NewDecoder(response.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// chunkRange formats the Content-Range header of a chunk
+func chunkRange(offset int64, length int, size int64) string {
+	return fmt.Sprintf("bytes %d-%d/%d", offset, offset+int64(length)-1, size)
+}
@@ -0,0 +1,168 @@
+package appwrite
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/appwrite/sdk-for-go/id"
+)
+
+// uploadServer records the chunks posted to the file creation endpoint
+type uploadServer struct {
+	mu     sync.Mutex
+	ranges []string
+	data   bytes.Buffer
+}
+
+func (u *uploadServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.ranges = append(u.ranges, r.Header.Get("Content-Range"))
+	io.Copy(&u.data, file)
+	fmt.Fprintf(w, `{"$id":"f1","bucketId":"b1","name":%q}`, r.MultipartForm.File["file"][0].Filename)
+}
+
+func TestCreateFileFromPipe(t *testing.T) {
+	tests := []struct {
+		name   string
+		size   int
+		ranges []string
+	}{
+		{"single request", 12, []string{""}},
+		{"chunked", ChunkSize + 10, []string{
+			fmt.Sprintf("bytes 0-%d/%d", ChunkSize-1, ChunkSize+10),
+			fmt.Sprintf("bytes %d-%d/%d", ChunkSize, ChunkSize+9, ChunkSize+10),
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			upload := &uploadServer{}
+			srv := httptest.NewServer(upload)
+			defer srv.Close()
+
+			client := NewClient()
+			client.SetEndpoint(srv.URL)
+			storage := NewStorage(client)
+
+			// os.File implements io.Seeker, but pipes can't seek
+			r, w, err := os.Pipe()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer r.Close()
+			content := bytes.Repeat([]byte("x"), tt.size)
+			go func() {
+				w.Write(content)
+				w.Close()
+			}()
+
+			file, err := storage.CreateFile("b1", "unique()", r, "data.bin", nil)
+			if err != nil {
+				t.Fatalf("CreateFile: %v", err)
+			}
+			if file.Name != "data.bin" {
+				t.Errorf("file name = %q, want data.bin", file.Name)
+			}
+			if !bytes.Equal(upload.data.Bytes(), content) {
+				t.Errorf("uploaded %d bytes, want %d", upload.data.Len(), len(content))
+			}
+			if fmt.Sprint(upload.ranges) != fmt.Sprint(tt.ranges) {
+				t.Errorf("Content-Range headers = %q, want %q", upload.ranges, tt.ranges)
+			}
+		})
+	}
+}
+
+func TestReaderSize(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	defer w.Close()
+
+	if size, err := readerSize(r); err != nil || size != -1 {
+		t.Errorf("readerSize(pipe) = %d, %v, want -1, nil", size, err)
+	}
+	reader := bytes.NewReader([]byte("hello"))
+	reader.ReadByte()
+	if size, err := readerSize(reader); err != nil || size != 4 {
+		t.Errorf("readerSize(bytes.Reader) = %d, %v, want 4, nil", size, err)
+	}
+}
+
+func TestCreateFileResume(t *testing.T) {
+	size := ChunkSize + 10
+	tests := []struct {
+		name     string
+		fileId   string
+		existing string
+		ranges   []string
+		err      error
+	}{
+		{"new file", "f1", "", []string{
+			fmt.Sprintf("bytes 0-%d/%d", ChunkSize-1, size),
+			fmt.Sprintf("bytes %d-%d/%d", ChunkSize, size-1, size),
+		}, nil},
+		{"partial upload", "f1", `{"$id":"f1","chunksTotal":2,"chunksUploaded":1}`, []string{
+			fmt.Sprintf("bytes %d-%d/%d", ChunkSize, size-1, size),
+		}, nil},
+		{"complete file", "f1", `{"$id":"f1","chunksTotal":2,"chunksUploaded":2}`, nil, ErrConflict},
+		{"invalid id", "_f1", "", nil, id.ErrInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			upload := &uploadServer{}
+			var probes int
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodPost {
+					upload.ServeHTTP(w, r)
+					return
+				}
+				probes++
+				if tt.existing == "" {
+					w.WriteHeader(http.StatusNotFound)
+					fmt.Fprint(w, `{"message":"File not found","code":404,"type":"storage_file_not_found"}`)
+					return
+				}
+				fmt.Fprint(w, tt.existing)
+			}))
+			defer srv.Close()
+
+			client := NewClient()
+			client.SetEndpoint(srv.URL)
+			storage := NewStorage(client)
+
+			_, err := storage.CreateFile("b1", tt.fileId, bytes.NewReader(bytes.Repeat([]byte("x"), size)), "data.bin", nil)
+			if tt.err != nil {
+				if !errors.Is(err, tt.err) {
+					t.Errorf("CreateFile error = %v, want %v", err, tt.err)
+				}
+			} else if err != nil {
+				t.Fatalf("CreateFile: %v", err)
+			}
+			if fmt.Sprint(upload.ranges) != fmt.Sprint(tt.ranges) {
+				t.Errorf("Content-Range headers = %q, want %q", upload.ranges, tt.ranges)
+			}
+			if tt.err == id.ErrInvalid && probes != 0 {
+				t.Errorf("an invalid id sent %d requests", probes)
+			}
+		})
+	}
+}
@@ -1,8 +1,8 @@
 package appwrite
 
 import (
+	"context"
 	"encoding/json"
-	"strings"
 )
 
 // Users service
@@ -46,6 +46,11 @@ func NewUsers(clt Client) Users {
 // List get a list of all the project users. You can use the query params to
 // filter your results.
 func (srv *Users) List(Search string, Limit int, Offset int, OrderType string) ([]UserObject, error) {
+	return srv.ListContext(context.Background(), Search, Limit, Offset, OrderType)
+}
+
+// ListContext is like List but cancels the request when ctx is done.
+func (srv *Users) ListContext(ctx context.Context, Search string, Limit int, Offset int, OrderType string) ([]UserObject, error) {
 	path := "/users"
 
 	params := map[string]interface{}{
@@ -55,7 +60,7 @@ func (srv *Users) List(Search string, Limit int, Offset int, OrderType string) (
 		"orderType": OrderType,
 	}
 
-	resp, err := srv.Client.CallAPI("GET", path, srv.Client.headers, params)
+	resp, err := srv.Client.CallAPIContext(ctx, "GET", path, srv.Client.headers, params)
 	if err != nil {
 		return nil, err
 	}
@@ -68,6 +73,11 @@ func (srv *Users) List(Search string, Limit int, Offset int, OrderType string) (
 
 // Create create a new user.
 func (srv *Users) Create(Email string, Password string, Name string) (map[string]interface{}, error) {
+	return srv.CreateContext(context.Background(), Email, Password, Name)
+}
+
+// CreateContext is like Create but cancels the request when ctx is done.
+func (srv *Users) CreateContext(ctx context.Context, Email string, Password string, Name string) (map[string]interface{}, error) {
 	path := "/users"
 
 	params := map[string]interface{}{
@@ -76,92 +86,132 @@ func (srv *Users) Create(Email string, Password string, Name string) (map[string
 		"name":     Name,
 	}
 
-	return srv.Client.Call("POST", path, srv.Client.headers, params)
+	return srv.Client.CallContext(ctx, "POST", path, srv.Client.headers, params)
 }
 
 // Get get user by its unique ID.
 func (srv *Users) Get(UserId string) (map[string]interface{}, error) {
-	r := strings.NewReplacer("{userId}", UserId)
+	return srv.GetContext(context.Background(), UserId)
+}
+
+// GetContext is like Get but cancels the request when ctx is done.
+func (srv *Users) GetContext(ctx context.Context, UserId string) (map[string]interface{}, error) {
+	r := pathReplacer("{userId}", UserId)
 	path := r.Replace("/users/{userId}")
 
 	params := map[string]interface{}{}
 
-	return srv.Client.Call("GET", path, srv.Client.headers, params)
+	return srv.Client.CallContext(ctx, "GET", path, srv.Client.headers, params)
 }
 
 // GetLogs get user activity logs list by its unique ID.
 func (srv *Users) GetLogs(UserId string) (map[string]interface{}, error) {
-	r := strings.NewReplacer("{userId}", UserId)
+	return srv.GetLogsContext(context.Background(), UserId)
+}
+
+// GetLogsContext is like GetLogs but cancels the request when ctx is done.
+func (srv *Users) GetLogsContext(ctx context.Context, UserId string) (map[string]interface{}, error) {
+	r := pathReplacer("{userId}", UserId)
 	path := r.Replace("/users/{userId}/logs")
 
 	params := map[string]interface{}{}
 
-	return srv.Client.Call("GET", path, srv.Client.headers, params)
+	return srv.Client.CallContext(ctx, "GET", path, srv.Client.headers, params)
 }
 
 // GetPrefs get user preferences by its unique ID.
 func (srv *Users) GetPrefs(UserId string) (map[string]interface{}, error) {
-	r := strings.NewReplacer("{userId}", UserId)
+	return srv.GetPrefsContext(context.Background(), UserId)
+}
+
+// GetPrefsContext is like GetPrefs but cancels the request when ctx is done.
+func (srv *Users) GetPrefsContext(ctx context.Context, UserId string) (map[string]interface{}, error) {
+	r := pathReplacer("{userId}", UserId)
 	path := r.Replace("/users/{userId}/prefs")
 
 	params := map[string]interface{}{}
 
-	return srv.Client.Call("GET", path, srv.Client.headers, params)
+	return srv.Client.CallContext(ctx, "GET", path, srv.Client.headers, params)
 }
 
 // UpdatePrefs update user preferences by its unique ID. You can pass only the
 // specific settings you wish to update.
 func (srv *Users) UpdatePrefs(UserId string, Prefs map[string]interface{}) (map[string]interface{}, error) {
-	r := strings.NewReplacer("{userId}", UserId)
+	return srv.UpdatePrefsContext(context.Background(), UserId, Prefs)
+}
+
+// UpdatePrefsContext is like UpdatePrefs but cancels the request when ctx is done.
+func (srv *Users) UpdatePrefsContext(ctx context.Context, UserId string, Prefs map[string]interface{}) (map[string]interface{}, error) {
+	r := pathReplacer("{userId}", UserId)
 	path := r.Replace("/users/{userId}/prefs")
 
 	params := map[string]interface{}{
 		"prefs": Prefs,
 	}
 
-	return srv.Client.Call("PATCH", path, srv.Client.headers, params)
+	return srv.Client.CallContext(ctx, "PATCH", path, srv.Client.headers, params)
 }
 
 // GetSessions get user sessions list by its unique ID.
 func (srv *Users) GetSessions(UserId string) (map[string]interface{}, error) {
-	r := strings.NewReplacer("{userId}", UserId)
+	return srv.GetSessionsContext(context.Background(), UserId)
+}
+
+// GetSessionsContext is like GetSessions but cancels the request when ctx is done.
+func (srv *Users) GetSessionsContext(ctx context.Context, UserId string) (map[string]interface{}, error) {
+	r := pathReplacer("{userId}", UserId)
 	path := r.Replace("/users/{userId}/sessions")
 
 	params := map[string]interface{}{}
 
-	return srv.Client.Call("GET", path, srv.Client.headers, params)
+	return srv.Client.CallContext(ctx, "GET", path, srv.Client.headers, params)
 }
 
 // DeleteSessions delete all user sessions by its unique ID.
 func (srv *Users) DeleteSessions(UserId string) (map[string]interface{}, error) {
-	r := strings.NewReplacer("{userId}", UserId)
+	return srv.DeleteSessionsContext(context.Background(), UserId)
+}
+
+// DeleteSessionsContext is like DeleteSessions but cancels the request when ctx is done.
+func (srv *Users) DeleteSessionsContext(ctx context.Context, UserId string) (map[string]interface{}, error) {
+	r := pathReplacer("{userId}", UserId)
 	path := r.Replace("/users/{userId}/sessions")
 
 	params := map[string]interface{}{}
 
-	return srv.Client.Call("DELETE", path, srv.Client.headers, params)
+	return srv.Client.CallContext(ctx, "DELETE", path, srv.Client.headers, params)
 }
 
 // DeleteSession delete user sessions by its unique ID.
 func (srv *Users) DeleteSession(UserId string, SessionId string) (map[string]interface{}, error) {
-	r := strings.NewReplacer("{userId}", UserId)
+	return srv.DeleteSessionContext(context.Background(), UserId, SessionId)
+}
+
+// DeleteSessionContext is like DeleteSession but cancels the request when ctx is done.
+func (srv *Users) DeleteSessionContext(ctx context.Context, UserId string, SessionId string) (map[string]interface{}, error) {
+	r := pathReplacer("{userId}", UserId)
 	path := r.Replace("/users/{userId}/sessions/:session")
 
 	params := map[string]interface{}{
 		"sessionId": SessionId,
 	}
 
-	return srv.Client.Call("DELETE", path, srv.Client.headers, params)
+	return srv.Client.CallContext(ctx, "DELETE", path, srv.Client.headers, params)
 }
 
 // UpdateStatus update user status by its unique ID.
 func (srv *Users) UpdateStatus(UserId string, Status string) (map[string]interface{}, error) {
-	r := strings.NewReplacer("{userId}", UserId)
+	return srv.UpdateStatusContext(context.Background(), UserId, Status)
+}
+
+// UpdateStatusContext is like UpdateStatus but cancels the request when ctx is done.
+func (srv *Users) UpdateStatusContext(ctx context.Context, UserId string, Status string) (map[string]interface{}, error) {
+	r := pathReplacer("{userId}", UserId)
 	path := r.Replace("/users/{userId}/status")
 
 	params := map[string]interface{}{
 		"status": Status,
 	}
 
-	return srv.Client.Call("PATCH", path, srv.Client.headers, params)
+	return srv.Client.CallContext(ctx, "PATCH", path, srv.Client.headers, params)
 }
@@ -1,9 +1,14 @@
 package appwrite
 
 import (
+	"encoding/json"
 	"fmt"
+	"net/url"
 	"reflect"
 	"strconv"
+	"strings"
+
+	"github.com/appwrite/sdk-for-go/id"
 )
 
 // ToString changes arg to string
@@ -22,15 +27,70 @@ func ToString(arg interface{}) string {
 		return strconv.FormatInt(v, 10)
 	case string:
 		return v
+	case bool:
+		return strconv.FormatBool(v)
 	case float32:
 		return strconv.FormatFloat(float64(v), 'f', -1, 32)
 	case float64:
 		return strconv.FormatFloat(v, 'f', -1, 64)
-	case fmt.Stringer:
-		return v.String()
 	case reflect.Value:
 		return ToString(v.Interface())
+	case fmt.Stringer:
+		return v.String()
 	default:
 		return ""
 	}
 }
+
+// addQueryParam adds val to the query string under key. Slices follow the
+// Appwrite array convention of repeating key[] once per element, and empty
+// values are left out.
+func addQueryParam(q url.Values, key string, val interface{}) {
+	if val == nil {
+		return
+	}
+	v := reflect.Indirect(reflect.ValueOf(val))
+	if !v.IsValid() {
+		return
+	}
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			q.Add(key+"[]", ToString(v.Index(i).Interface()))
+		}
+	case reflect.Map:
+		if v.Len() == 0 {
+			return
+		}
+		encoded, err := json.Marshal(val)
+		if err == nil {
+			q.Add(key, string(encoded))
+		}
+	default:
+		if str := ToString(v.Interface()); str != "" {
+			q.Add(key, str)
+		}
+	}
+}
+
+// validateId checks that the ID given for a new resource is either unique()
+// or a valid custom ID, so malformed IDs never reach the server
+func validateId(name, value string) error {
+	if err := id.Validate(value); err != nil {
+		return fmt.Errorf("appwrite: %s: %w", name, err)
+	}
+	return nil
+}
+
+// pathReplacer returns a replacer filling the placeholders of a path with
+// the given values, escaped so that they can't alter the path or add a query
+func pathReplacer(oldnew ...string) *strings.Replacer {
+	escaped := make([]string, len(oldnew))
+	for i, s := range oldnew {
+		if i%2 == 1 {
+			s = url.PathEscape(s)
+		}
+		escaped[i] = s
+	}
+	return strings.NewReplacer(escaped...)
+}
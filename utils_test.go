@@ -0,0 +1,139 @@
+package appwrite
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/appwrite/sdk-for-go/id"
+)
+
+func TestPathIdsAreEscaped(t *testing.T) {
+	var paths, queries []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.EscapedPath())
+		queries = append(queries, r.URL.RawQuery)
+		fmt.Fprint(w, `{"$id":"x"}`)
+	}))
+	defer srv.Close()
+
+	client := NewClient()
+	client.SetEndpoint(srv.URL)
+	db := NewDatabase(client)
+	storage := NewStorage(client)
+
+	db.GetDocument("main", "posts", "a/../b")
+	db.DeleteDocument("main", "posts", "x?y")
+	storage.GetFile("b#1", "f 1")
+
+	want := []string{
+		"/databases/main/collections/posts/documents/a%2F..%2Fb",
+		"/databases/main/collections/posts/documents/x%3Fy",
+		"/storage/buckets/b%231/files/f%201",
+	}
+	if fmt.Sprint(paths) != fmt.Sprint(want) {
+		t.Errorf("paths = %q, want %q", paths, want)
+	}
+	for _, query := range queries {
+		if query != "" {
+			t.Errorf("IDs leaked into the query string: %q", query)
+		}
+	}
+}
+
+func TestCreateValidatesNewIds(t *testing.T) {
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		fmt.Fprint(w, `{"$id":"x"}`)
+	}))
+	defer srv.Close()
+
+	client := NewClient()
+	client.SetEndpoint(srv.URL)
+	db := NewDatabase(client)
+
+	if _, err := db.CreateDocument("main", "posts", "_bad", nil, nil); !errors.Is(err, id.ErrInvalid) {
+		t.Errorf("CreateDocument with an invalid ID: err = %v, want id.ErrInvalid", err)
+	}
+	if _, err := db.CreateCollection("main", "has space", "Posts", nil, false); !errors.Is(err, id.ErrInvalid) {
+		t.Errorf("CreateCollection with an invalid ID: err = %v, want id.ErrInvalid", err)
+	}
+	if requests != 0 {
+		t.Errorf("invalid IDs sent %d requests", requests)
+	}
+
+	// IDs of existing resources given in the body aren't new IDs
+	if _, err := db.CreateRelationshipAttribute("main", "posts", "related_collection", RelationOneToOne, false, "author", "", OnDeleteRestrict); err != nil {
+		t.Errorf("CreateRelationshipAttribute: %v", err)
+	}
+	if _, err := db.CreateDocument("main", "posts", id.Unique(), nil, nil); err != nil {
+		t.Errorf("CreateDocument with unique(): %v", err)
+	}
+}
+
+func TestAddQueryParam(t *testing.T) {
+	limit := 25
+	tests := []struct {
+		name string
+		val  interface{}
+		want string
+	}{
+		{"int", 25, "p=25"},
+		{"int pointer", &limit, "p=25"},
+		{"int64", int64(9007199254740993), "p=9007199254740993"},
+		{"bool", true, "p=true"},
+		{"float", 0.5, "p=0.5"},
+		{"string", "a b", "p=a+b"},
+		{"empty string", "", ""},
+		{"nil", nil, ""},
+		{"string slice", []string{`limit(25)`, `orderAsc("title")`}, "p%5B%5D=limit%2825%29&p%5B%5D=orderAsc%28%22title%22%29"},
+		{"int slice", []int{1, 2}, "p%5B%5D=1&p%5B%5D=2"},
+		{"bool slice", []bool{true, false}, "p%5B%5D=true&p%5B%5D=false"},
+		{"empty slice", []string{}, ""},
+		{"map", map[string]interface{}{"a": 1}, "p=%7B%22a%22%3A1%7D"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := url.Values{}
+			addQueryParam(q, "p", tt.val)
+			if got := q.Encode(); got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetParamsAreEncoded(t *testing.T) {
+	var queries []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+		fmt.Fprint(w, `{}`)
+	}))
+	defer srv.Close()
+
+	client := NewClient()
+	client.SetEndpoint(srv.URL)
+	storage := NewStorage(client)
+	avatars := NewAvatars(client)
+
+	storage.ListBuckets("", 10, 20, "")
+	avatars.GetBrowser("ch", 100, 50, 90)
+
+	want := []string{
+		"limit=10&offset=20",
+		"height=50&quality=90&width=100",
+	}
+	for i, query := range queries {
+		if values, _ := url.ParseQuery(query); values.Encode() != want[i] {
+			t.Errorf("request %d sent query %q, want %q", i, query, want[i])
+		}
+	}
+	if len(queries) != len(want) {
+		t.Errorf("sent %d requests, want %d", len(queries), len(want))
+	}
+}
@@ -0,0 +1,139 @@
+package appwrite
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Statuses of attributes and indexes
+const (
+	StatusAvailable  = "available"
+	StatusProcessing = "processing"
+	StatusDeleting   = "deleting"
+	StatusStuck      = "stuck"
+	StatusFailed     = "failed"
+)
+
+const (
+	waitBaseDelay = 250 * time.Millisecond
+	waitMaxDelay  = 5 * time.Second
+)
+
+// StatusError is returned when an attribute or index ends up failed or stuck
+// instead of becoming available
+type StatusError struct {
+	Kind    string
+	Key     string
+	Status  string
+	Message string
+}
+
+// Error implements the error interface
+func (e *StatusError) Error() string {
+	if e.Message != "" {
+		return fmt.Sprintf("appwrite: %s %q is %s: %s", e.Kind, e.Key, e.Status, e.Message)
+	}
+	return fmt.Sprintf("appwrite: %s %q is %s", e.Kind, e.Key, e.Status)
+}
+
+// WaitForAttribute poll the collection until the attribute key is available.
+func (srv *Database) WaitForAttribute(ctx context.Context, databaseId, collectionId, key string) (*Attribute, error) {
+	var result *Attribute
+	_, err := srv.waitFor(ctx, databaseId, collectionId, func(collection *Collection) (string, error) {
+		for i := range collection.Attributes {
+			attribute := &collection.Attributes[i]
+			if attribute.Key == key {
+				result = attribute
+				return checkStatus("attribute", key, attribute.Status, attribute.Error)
+			}
+		}
+		return "", fmt.Errorf("appwrite: attribute %q not found in collection %q", key, collectionId)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// WaitForIndex poll the collection until the index key is available.
+func (srv *Database) WaitForIndex(ctx context.Context, databaseId, collectionId, key string) (*Index, error) {
+	var result *Index
+	_, err := srv.waitFor(ctx, databaseId, collectionId, func(collection *Collection) (string, error) {
+		for i := range collection.Indexes {
+			index := &collection.Indexes[i]
+			if index.Key == key {
+				result = index
+				return checkStatus("index", key, index.Status, index.Error)
+			}
+		}
+		return "", fmt.Errorf("appwrite: index %q not found in collection %q", key, collectionId)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// WaitForCollectionReady poll the collection until every attribute and index
+// it holds is available.
+func (srv *Database) WaitForCollectionReady(ctx context.Context, databaseId, collectionId string) (*Collection, error) {
+	return srv.waitFor(ctx, databaseId, collectionId, func(collection *Collection) (string, error) {
+		for _, attribute := range collection.Attributes {
+			pending, err := checkStatus("attribute", attribute.Key, attribute.Status, attribute.Error)
+			if pending != "" || err != nil {
+				return pending, err
+			}
+		}
+		for _, index := range collection.Indexes {
+			pending, err := checkStatus("index", index.Key, index.Status, index.Error)
+			if pending != "" || err != nil {
+				return pending, err
+			}
+		}
+		return "", nil
+	})
+}
+
+// waitFor polls the collection with exponential backoff until check reports
+// nothing pending, check fails or ctx is done
+func (srv *Database) waitFor(ctx context.Context, databaseId, collectionId string, check func(*Collection) (string, error)) (*Collection, error) {
+	delay := waitBaseDelay
+	for {
+		collection, err := srv.GetCollectionContext(ctx, databaseId, collectionId)
+		if err != nil {
+			return nil, err
+		}
+		pending, err := check(collection)
+		if err != nil {
+			return nil, err
+		}
+		if pending == "" {
+			return collection, nil
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, fmt.Errorf("appwrite: %s: %w", pending, ctx.Err())
+		case <-timer.C:
+		}
+
+		if delay *= 2; delay > waitMaxDelay {
+			delay = waitMaxDelay
+		}
+	}
+}
+
+// checkStatus describes what is still pending for a resource, or returns a
+// *StatusError when it won't ever become available
+func checkStatus(kind, key, status, message string) (string, error) {
+	switch status {
+	case StatusAvailable:
+		return "", nil
+	case StatusFailed, StatusStuck:
+		return "", &StatusError{Kind: kind, Key: key, Status: status, Message: message}
+	}
+	return fmt.Sprintf("%s %q is %s", kind, key, status), nil
+}
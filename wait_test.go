@@ -0,0 +1,159 @@
+package appwrite
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// statusServer serves a collection whose title attribute and title_idx index
+// go through statuses, one per poll, staying in the last one
+type statusServer struct {
+	mu       sync.Mutex
+	statuses []string
+	polls    int
+}
+
+func (s *statusServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	status := s.statuses[min(s.polls, len(s.statuses)-1)]
+	s.polls++
+	s.mu.Unlock()
+
+	message := ""
+	if status == StatusFailed {
+		message = "Invalid default value"
+	}
+	fmt.Fprintf(w, `{"$id":"posts",
+		"attributes":[{"key":"views","type":"integer","status":"available"},{"key":"title","type":"string","status":%[1]q,"error":%[2]q}],
+		"indexes":[{"key":"title_idx","type":"key","status":%[1]q,"error":%[2]q,"attributes":["title"]}]}`, status, message)
+}
+
+func newStatusServer(t *testing.T, statuses ...string) (*Database, *statusServer) {
+	t.Helper()
+	s := &statusServer{statuses: statuses}
+	srv := httptest.NewServer(s)
+	t.Cleanup(srv.Close)
+
+	client := NewClient()
+	client.SetEndpoint(srv.URL)
+	db := NewDatabase(client)
+	return &db, s
+}
+
+// waiters runs each of the wait helpers on the title attribute or index
+var waiters = []struct {
+	name string
+	wait func(ctx context.Context, db *Database) (string, error)
+}{
+	{"attribute", func(ctx context.Context, db *Database) (string, error) {
+		attribute, err := db.WaitForAttribute(ctx, "main", "posts", "title")
+		if err != nil {
+			return "", err
+		}
+		return attribute.Status, nil
+	}},
+	{"index", func(ctx context.Context, db *Database) (string, error) {
+		index, err := db.WaitForIndex(ctx, "main", "posts", "title_idx")
+		if err != nil {
+			return "", err
+		}
+		return index.Status, nil
+	}},
+	{"collection", func(ctx context.Context, db *Database) (string, error) {
+		collection, err := db.WaitForCollectionReady(ctx, "main", "posts")
+		if err != nil {
+			return "", err
+		}
+		return collection.Indexes[0].Status, nil
+	}},
+}
+
+func TestWaitForAvailable(t *testing.T) {
+	for _, w := range waiters {
+		t.Run(w.name, func(t *testing.T) {
+			t.Parallel()
+			db, s := newStatusServer(t, StatusProcessing, StatusProcessing, StatusAvailable)
+
+			status, err := w.wait(context.Background(), db)
+			if err != nil {
+				t.Fatalf("wait: %v", err)
+			}
+			if status != StatusAvailable || s.polls != 3 {
+				t.Errorf("got status %q after %d polls, want %q after 3", status, s.polls, StatusAvailable)
+			}
+		})
+	}
+}
+
+func TestWaitForFailed(t *testing.T) {
+	for _, status := range []string{StatusFailed, StatusStuck} {
+		for _, w := range waiters {
+			t.Run(status+"/"+w.name, func(t *testing.T) {
+				t.Parallel()
+				db, s := newStatusServer(t, StatusProcessing, status)
+
+				_, err := w.wait(context.Background(), db)
+				var statusErr *StatusError
+				if !errors.As(err, &statusErr) {
+					t.Fatalf("error = %v, want a *StatusError", err)
+				}
+				if statusErr.Status != status || s.polls != 2 {
+					t.Errorf("got %v after %d polls, want status %q after 2", err, s.polls, status)
+				}
+				if status == StatusFailed && statusErr.Message != "Invalid default value" {
+					t.Errorf("message = %q, want the one of the server", statusErr.Message)
+				}
+			})
+		}
+	}
+}
+
+func TestWaitForTimeout(t *testing.T) {
+	for _, w := range waiters {
+		t.Run(w.name, func(t *testing.T) {
+			t.Parallel()
+			db, s := newStatusServer(t, StatusProcessing)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			defer cancel()
+			start := time.Now()
+			_, err := w.wait(ctx, db)
+			if !errors.Is(err, context.DeadlineExceeded) {
+				t.Fatalf("error = %v, want context.DeadlineExceeded", err)
+			}
+			if elapsed := time.Since(start); elapsed > waitBaseDelay {
+				t.Errorf("returned after %v, not when ctx was done", elapsed)
+			}
+			if s.polls != 1 {
+				t.Errorf("%d polls, want 1", s.polls)
+			}
+		})
+	}
+}
+
+func TestWaitForCancel(t *testing.T) {
+	db, _ := newStatusServer(t, StatusProcessing)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := db.WaitForAttribute(ctx, "main", "posts", "title"); !errors.Is(err, context.Canceled) {
+		t.Errorf("error = %v, want context.Canceled", err)
+	}
+}
+
+func TestWaitForMissing(t *testing.T) {
+	db, _ := newStatusServer(t, StatusAvailable)
+
+	if _, err := db.WaitForAttribute(context.Background(), "main", "posts", "body"); err == nil {
+		t.Error("waiting for a missing attribute succeeded")
+	}
+	if _, err := db.WaitForIndex(context.Background(), "main", "posts", "body_idx"); err == nil {
+		t.Error("waiting for a missing index succeeded")
+	}
+}